@@ -38,8 +38,8 @@ func (event EventHandler) Execute(ccEvent *fab.CCEvent) {
 		var logStr string
 		nerr := json.Unmarshal(ccEvent.Payload, &logStr)
 		if nerr != nil {
-			fmt.Println("error unmarshalling log: ", nerr)
-			return
+			// Structured payloads are logged as they are
+			logStr = string(ccEvent.Payload)
 		}
 
 		if len(logStr) > 0 {
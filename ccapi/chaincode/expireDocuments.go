@@ -0,0 +1,53 @@
+package chaincode
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+)
+
+// ScheduleDocumentExpiration invokes the expireDocuments transaction every interval
+// until the context is cancelled. Each run is repeated while the chaincode reports
+// there are more expired documents than the limit of a single call.
+func ScheduleDocumentExpiration(ctx context.Context, channelName, ccName string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expireDocuments(channelName, ccName)
+		}
+	}
+}
+
+func expireDocuments(channelName, ccName string) {
+	for {
+		res, _, err := Invoke(channelName, ccName, "expireDocuments", nil, nil)
+		if err != nil {
+			log.Println("Failed to expire documents: ", err)
+			return
+		}
+
+		var result struct {
+			Expired []string `json:"expired"`
+			HasMore bool     `json:"hasMore"`
+		}
+		err = json.Unmarshal(res.Payload, &result)
+		if err != nil {
+			log.Println("Failed to unmarshal expireDocuments response: ", err)
+			return
+		}
+
+		if len(result.Expired) > 0 {
+			log.Println("Expired documents: ", result.Expired)
+		}
+
+		if !result.HasMore {
+			return
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -36,6 +37,15 @@ func main() {
 
 	chaincode.RegisterForEvents()
 
+	// Periodically expire waiting documents past their timeout
+	if interval := os.Getenv("EXPIRE_DOCUMENTS_INTERVAL"); interval != "" {
+		d, err := time.ParseDuration(interval)
+		if err != nil {
+			log.Fatal("Invalid EXPIRE_DOCUMENTS_INTERVAL: ", err)
+		}
+		go chaincode.ScheduleDocumentExpiration(ctx, os.Getenv("CHANNEL"), os.Getenv("CCNAME"), d)
+	}
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt)
 
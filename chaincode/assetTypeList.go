@@ -10,14 +10,24 @@ import (
 var assetTypeList = []assets.AssetType{
 	assettypes.Secret,
 	assettypes.User,
+	assettypes.PersonalData,
+	assettypes.Consent,
+	assettypes.Company,
 
 	documentassettypes.Document,
+	documentassettypes.Delegation,
+	documentassettypes.Signature,
 
 	contractassettypes.AutoExecutableContract,
 	contractassettypes.Clause,
 	contractassettypes.Deduction,
 	contractassettypes.Credit,
 	contractassettypes.Payment,
+	contractassettypes.PaymentDetails,
 	contractassettypes.Template,
 	contractassettypes.TemplateClause,
+	contractassettypes.IndexRate,
+	contractassettypes.HolidayCalendar,
+	contractassettypes.ClauseExecution,
+	contractassettypes.Amendment,
 }
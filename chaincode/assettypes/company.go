@@ -0,0 +1,39 @@
+package assettypes
+
+import "github.com/hyperledger-labs/cc-tools/assets"
+
+// Company is a legal entity party. Contracts and documents reference it through its party user,
+// the user asset with the company set, and its legal representatives act on its behalf.
+var Company = assets.AssetType{
+	Tag:         "company",
+	Label:       "Company",
+	Description: "Legal entity party",
+
+	Props: []assets.AssetProp{
+		{
+			Required: true,
+			IsKey:    true,
+			Tag:      "cnpj",
+			Label:    "CNPJ",
+			DataType: "cnpj",
+		},
+		{
+			Required: true,
+			Tag:      "legalName",
+			Label:    "Legal Name",
+			DataType: "string",
+		},
+		{
+			Tag:      "tradeName",
+			Label:    "Trade Name",
+			DataType: "string",
+		},
+		{
+			Required:    true,
+			Tag:         "representatives",
+			Label:       "Legal Representatives",
+			DataType:    "[]->user",
+			Description: "Users allowed to act and sign on behalf of the company",
+		},
+	},
+}
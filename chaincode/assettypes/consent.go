@@ -0,0 +1,51 @@
+package assettypes
+
+import "github.com/hyperledger-labs/cc-tools/assets"
+
+// Consent records a user consent to the processing of their personal data for a purpose
+var Consent = assets.AssetType{
+	Tag:         "consent",
+	Label:       "Consent",
+	Description: "Consent of a user to the processing of their personal data",
+
+	Props: []assets.AssetProp{
+		{
+			Required:    true,
+			IsKey:       true,
+			Tag:         "id",
+			Label:       "ID",
+			DataType:    "string",
+			Description: "ID of the transaction that recorded the consent",
+		},
+		{
+			Required: true,
+			Tag:      "user",
+			Label:    "User",
+			DataType: "->user",
+		},
+		{
+			Required: true,
+			Tag:      "purpose",
+			Label:    "Purpose",
+			DataType: "string",
+		},
+		{
+			Required:    true,
+			Tag:         "policyVersion",
+			Label:       "Policy Version",
+			DataType:    "string",
+			Description: "Version of the privacy policy the user agreed to",
+		},
+		{
+			Required: true,
+			Tag:      "grantedAt",
+			Label:    "Granted At",
+			DataType: "datetime",
+		},
+		{
+			Tag:      "revokedAt",
+			Label:    "Revoked At",
+			DataType: "datetime",
+		},
+	},
+}
@@ -0,0 +1,95 @@
+package contractassettypes
+
+import "github.com/hyperledger-labs/cc-tools/assets"
+
+var Amendment = assets.AssetType{
+	Tag:         "amendment",
+	Label:       "Amendment",
+	Description: "Proposed change to the clauses, participants or status of a contract, applied once the owner and every participant approve it",
+
+	Props: []assets.AssetProp{
+		{
+			Required:    true,
+			IsKey:       true,
+			Tag:         "id",
+			Label:       "ID",
+			DataType:    "string",
+			Description: "ID of the transaction that proposed the amendment",
+		},
+		{
+			Required: true,
+			Tag:      "autoExecutableContract",
+			Label:    "Auto Executable Contract",
+			DataType: "->autoExecutableContract",
+		},
+		{
+			Required: true,
+			Tag:      "proposer",
+			Label:    "Proposer",
+			DataType: "->user",
+		},
+		{
+			Tag:      "description",
+			Label:    "Description",
+			DataType: "string",
+		},
+		{
+			Tag:         "addClauses",
+			Label:       "Add Clauses",
+			DataType:    "[]@object",
+			Description: "Clauses to add, in the same format as the addClauses transaction",
+		},
+		{
+			Tag:      "removeClauses",
+			Label:    "Remove Clauses",
+			DataType: "[]->clause",
+		},
+		{
+			Tag:         "parameterChanges",
+			Label:       "Parameter Changes",
+			DataType:    "[]@object",
+			Description: "Parameters to change on existing clauses, e.g. [{\"clause\": {\"@key\": \"clause:...\"}, \"parameters\": {}}]",
+		},
+		{
+			Tag:         "participants",
+			Label:       "Participants",
+			DataType:    "[]->user",
+			Description: "New participants of the contract, replacing the current ones",
+		},
+		{
+			Tag:         "contractStatus",
+			Label:       "Contract Status",
+			DataType:    "contractStatus",
+			Description: "Status the contract moves to, finalized or cancelled",
+		},
+		{
+			Tag:      "approvals",
+			Label:    "Approvals",
+			DataType: "[]->user",
+		},
+		{
+			Tag:         "rejection",
+			Label:       "Rejection",
+			DataType:    "@object",
+			Description: "User who rejected the amendment, the reason and when",
+		},
+		{
+			Required:    true,
+			Tag:         "status",
+			Label:       "Status",
+			DataType:    "string",
+			Description: "pending, applied or rejected",
+		},
+		{
+			Required: true,
+			Tag:      "proposedAt",
+			Label:    "Proposed At",
+			DataType: "datetime",
+		},
+		{
+			Tag:      "appliedAt",
+			Label:    "Applied At",
+			DataType: "datetime",
+		},
+	},
+}
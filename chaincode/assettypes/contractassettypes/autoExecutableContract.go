@@ -16,10 +16,16 @@ var AutoExecutableContract = assets.AssetType{
 			DataType: "string",
 		},
 		{
-			Required: true,
-			Tag:      "signatureDate",
-			Label:    "Signature Date",
-			DataType: "datetime",
+			Tag:         "signatureDate",
+			Label:       "Signature Date",
+			DataType:    "datetime",
+			Description: "Date of the last signature of the contract document, when linked to one",
+		},
+		{
+			Tag:         "document",
+			Label:       "Document",
+			DataType:    "->document",
+			Description: "Document with the signed text of the contract",
 		},
 		{
 			Tag:      "clauses",
@@ -32,16 +38,18 @@ var AutoExecutableContract = assets.AssetType{
 			DataType: "@object",
 		},
 		{
-			Required: true,
-			IsKey:    true,
-			Tag:      "owner",
-			Label:    "Owner",
-			DataType: "->user",
+			Required:    true,
+			IsKey:       true,
+			Tag:         "owner",
+			Label:       "Owner",
+			DataType:    "->user",
+			Description: "A natural person or the party user of a company",
 		},
 		{
-			Tag:      "participants",
-			Label:    "Participants",
-			DataType: "[]->user",
+			Tag:         "participants",
+			Label:       "Participants",
+			DataType:    "[]->user",
+			Description: "Natural persons or party users of companies",
 		},
 		{
 			Tag:         "dates",
@@ -49,5 +57,29 @@ var AutoExecutableContract = assets.AssetType{
 			DataType:    "@object",
 			Description: "This includes all the dates that are linked to the contract.",
 		},
+		{
+			Tag:         "status",
+			Label:       "Status",
+			DataType:    "contractStatus",
+			Description: "Contracts without a status are active",
+		},
+		{
+			Tag:         "endDate",
+			Label:       "End Date",
+			DataType:    "datetime",
+			Description: "Active or suspended contracts expire after this date",
+		},
+		{
+			Tag:         "amendments",
+			Label:       "Amendments",
+			DataType:    "[]->amendment",
+			Description: "Amendments applied to the contract, in the order they were applied",
+		},
+		{
+			Tag:         "template",
+			Label:       "Template",
+			DataType:    "->template",
+			Description: "Template the contract was created from, if any",
+		},
 	},
 }
@@ -0,0 +1,75 @@
+package contractassettypes
+
+import "github.com/hyperledger-labs/cc-tools/assets"
+
+// ClauseExecution records the outcome of each execution of a clause, so earlier results are kept
+var ClauseExecution = assets.AssetType{
+	Tag:         "clauseExecution",
+	Label:       "Clause Execution",
+	Description: "Record of a clause execution",
+
+	Props: []assets.AssetProp{
+		{
+			Required: true,
+			IsKey:    true,
+			Tag:      "txId",
+			Label:    "Transaction ID",
+			DataType: "string",
+		},
+		{
+			Required: true,
+			IsKey:    true,
+			Tag:      "clause",
+			Label:    "Clause",
+			DataType: "->clause",
+		},
+		{
+			Required: true,
+			Tag:      "autoExecutableContract",
+			Label:    "Auto Executable Contract",
+			DataType: "->autoExecutableContract",
+		},
+		{
+			Required: true,
+			Tag:      "timestamp",
+			Label:    "Timestamp",
+			DataType: "datetime",
+		},
+		{
+			Tag:      "actionType",
+			Label:    "Action Type",
+			DataType: "actionType",
+		},
+		{
+			Tag:         "inputs",
+			Label:       "Inputs",
+			Description: "Inputs the clause action was executed with: its parameters and inputs, the contract data, the values loaded from the ledger and the execution date. Payment details are left out",
+			DataType:    "@object",
+		},
+		{
+			Tag:      "resultData",
+			Label:    "Result Data",
+			DataType: "@object",
+		},
+		{
+			Tag:      "meta",
+			Label:    "Meta",
+			DataType: "@object",
+		},
+		{
+			Tag:      "success",
+			Label:    "Success",
+			DataType: "boolean",
+		},
+		{
+			Tag:      "feedback",
+			Label:    "Feedback",
+			DataType: "string",
+		},
+		{
+			Tag:      "shouldFinalize",
+			Label:    "Should Finalize",
+			DataType: "boolean",
+		},
+	},
+}
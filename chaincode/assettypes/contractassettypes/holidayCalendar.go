@@ -0,0 +1,40 @@
+package contractassettypes
+
+import "github.com/hyperledger-labs/cc-tools/assets"
+
+// HolidayCalendar lists the holidays of a country, state or municipality, used to count business days
+var HolidayCalendar = assets.AssetType{
+	Tag:         "holidayCalendar",
+	Label:       "Holiday Calendar",
+	Description: "National, state or municipal holidays",
+
+	Props: []assets.AssetProp{
+		{
+			Required:    true,
+			IsKey:       true,
+			Tag:         "name",
+			Label:       "Name",
+			Description: "Calendar name, e.g. BR or BR-SP-Sao Paulo",
+			DataType:    "string",
+			Writers:     []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Tag:         "scope",
+			Label:       "Scope",
+			Description: "national, state or municipal",
+			DataType:    "string",
+		},
+		{
+			Tag:         "holidays",
+			Label:       "Holidays",
+			Description: "Holidays that happen on a single date, e.g. Carnival or Good Friday",
+			DataType:    "[]datetime",
+		},
+		{
+			Tag:         "recurringHolidays",
+			Label:       "Recurring Holidays",
+			Description: "Holidays on the same day every year, in MM-DD format, e.g. 12-25",
+			DataType:    "[]string",
+		},
+	},
+}
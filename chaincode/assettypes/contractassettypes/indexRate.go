@@ -0,0 +1,44 @@
+package contractassettypes
+
+import "github.com/hyperledger-labs/cc-tools/assets"
+
+// IndexRate is the monthly rate of a price or interest index (e.g. IPCA, IGP-M, SELIC, CDI)
+// Only the organizations allowed to publish index rates can write it
+var IndexRate = assets.AssetType{
+	Tag:         "indexRate",
+	Label:       "Index Rate",
+	Description: "Monthly rate of a price or interest index",
+
+	Props: []assets.AssetProp{
+		{
+			Required:    true,
+			IsKey:       true,
+			Tag:         "index",
+			Label:       "Index",
+			Description: "Index name, e.g. IPCA, IGP-M, SELIC or CDI",
+			DataType:    "string",
+			Writers:     []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Required:    true,
+			IsKey:       true,
+			Tag:         "date",
+			Label:       "Date",
+			Description: "Reference month of the rate",
+			DataType:    "datetime",
+		},
+		{
+			Required:    true,
+			Tag:         "value",
+			Label:       "Value",
+			Description: "Rate of the month, in percent",
+			DataType:    "number",
+		},
+		{
+			Tag:         "source",
+			Label:       "Source",
+			Description: "Institution that published the rate, e.g. IBGE or FGV",
+			DataType:    "string",
+		},
+	},
+}
@@ -22,10 +22,16 @@ var Payment = assets.AssetType{
 			DataType: "sha256",
 		},
 		{
-			Required: true,
-			Tag:      "receiptUrl",
-			Label:    "receiptUrl",
-			DataType: "string",
+			Tag:         "receiptUrl",
+			Label:       "receiptUrl",
+			DataType:    "string",
+			Description: "Only set on payments made before the payment details moved to the paymentDetails collection, until migratePaymentDetails moves it there",
+		},
+		{
+			Tag:         "detailsHash",
+			Label:       "Details Hash",
+			DataType:    "sha256",
+			Description: "Salted hash of the private payment details",
 		},
 		{
 			Required: true,
@@ -46,5 +52,17 @@ var Payment = assets.AssetType{
 			Label:    "clause",
 			DataType: "->clause",
 		},
+		{
+			Tag:         "deductions",
+			Label:       "Deductions",
+			DataType:    "[]->deduction",
+			Description: "Deductions settled by the payment",
+		},
+		{
+			Tag:         "credits",
+			Label:       "Credits",
+			DataType:    "[]->credit",
+			Description: "Credits settled by the payment",
+		},
 	},
 }
@@ -0,0 +1,59 @@
+package contractassettypes
+
+import "github.com/hyperledger-labs/cc-tools/assets"
+
+// PaymentDetails holds the sensitive payment inputs, kept off the world state.
+// The public payment asset stores only their salted hash.
+// A single collection holds the details of every contract: collections are part of the
+// chaincode definition and cannot be created per contract at runtime, so each entry
+// references its contract and clause instead.
+// Collections.json configuration is necessary
+var PaymentDetails = assets.AssetType{
+	Tag:         "paymentDetails",
+	Label:       "Payment Details",
+	Description: "Receipt URL and payment provider identifiers of a payment",
+
+	Readers: []string{"org1MSP", "orgMSP"},
+	Props: []assets.AssetProp{
+		{
+			Required: true,
+			IsKey:    true,
+			Tag:      "hash",
+			Label:    "Hash",
+			DataType: "sha256",
+		},
+		{
+			Required: true,
+			Tag:      "autoExecutableContract",
+			Label:    "Contract",
+			DataType: "->autoExecutableContract",
+		},
+		{
+			Required: true,
+			Tag:      "clause",
+			Label:    "Clause",
+			DataType: "->clause",
+		},
+		{
+			Tag:      "receiptUrl",
+			Label:    "Receipt URL",
+			DataType: "string",
+		},
+		{
+			Tag:      "stripeToken",
+			Label:    "Stripe Token",
+			DataType: "string",
+		},
+		{
+			Tag:      "payPalTransactionID",
+			Label:    "PayPal Transaction ID",
+			DataType: "string",
+		},
+		{
+			Required: true,
+			Tag:      "salt",
+			Label:    "Salt",
+			DataType: "string",
+		},
+	},
+}
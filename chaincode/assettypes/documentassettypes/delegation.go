@@ -0,0 +1,74 @@
+package documentassettypes
+
+import "github.com/hyperledger-labs/cc-tools/assets"
+
+// Delegation lets a user sign documents on behalf of another while it is valid
+var Delegation = assets.AssetType{
+	Tag:         "delegation",
+	Label:       "Delegation",
+	Description: "Signing authority granted by a user to a delegate",
+
+	Props: []assets.AssetProp{
+		{
+			Required:    true,
+			IsKey:       true,
+			Tag:         "id",
+			Label:       "ID",
+			DataType:    "string",
+			Description: "ID of the transaction that granted the delegation",
+		},
+		{
+			Required: true,
+			Tag:      "grantor",
+			Label:    "Grantor",
+			DataType: "->user",
+		},
+		{
+			Required: true,
+			Tag:      "delegate",
+			Label:    "Delegate",
+			DataType: "->user",
+		},
+		{
+			Required:    true,
+			Tag:         "scope",
+			Label:       "Scope",
+			DataType:    "string",
+			Description: "document, owner or all",
+		},
+		{
+			Tag:         "document",
+			Label:       "Document",
+			DataType:    "->document",
+			Description: "Only document the delegate can sign when the scope is document",
+		},
+		{
+			Tag:         "documentOwner",
+			Label:       "Document Owner",
+			DataType:    "->user",
+			Description: "Owner of the documents the delegate can sign when the scope is owner",
+		},
+		{
+			Required: true,
+			Tag:      "validFrom",
+			Label:    "Valid From",
+			DataType: "datetime",
+		},
+		{
+			Required: true,
+			Tag:      "validUntil",
+			Label:    "Valid Until",
+			DataType: "datetime",
+		},
+		{
+			Tag:      "revoked",
+			Label:    "Revoked",
+			DataType: "boolean",
+		},
+		{
+			Tag:      "revokedAt",
+			Label:    "Revoked At",
+			DataType: "datetime",
+		},
+	},
+}
@@ -27,10 +27,11 @@ var Document = assets.AssetType{
 			Required: true,
 		},
 		{
-			Tag:      "requiredSignatures",
-			Label:    "requiredSignatures",
-			DataType: "[]->user",
-			Required: true,
+			Tag:         "requiredSignatures",
+			Label:       "requiredSignatures",
+			DataType:    "[]->user",
+			Required:    true,
+			Description: "Natural persons or party users of companies, which sign through a legal representative",
 		},
 		{
 			Tag:      "successfulSignatures",
@@ -42,6 +43,12 @@ var Document = assets.AssetType{
 			Label:    "rejectedSignatures",
 			DataType: "[]->user",
 		},
+		{
+			Tag:         "signingOrder",
+			Label:       "Signing Order",
+			DataType:    "[]@object",
+			Description: "Optional steps in which the required signers must sign. Signers of the same step sign in parallel. e.g. [{\"signers\": [company]}, {\"signers\": [client]}, {\"signers\": [witness1, witness2]}]",
+		},
 		{
 			Tag:      "originalDocURL",
 			Label:    "originalDocURL",
@@ -60,15 +67,64 @@ var Document = assets.AssetType{
 			Required: true,
 		},
 		{
-			Tag:      "owner",
-			Label:    "Owner",
-			DataType: "->user",
-			Required: true,
+			Tag:         "owner",
+			Label:       "Owner",
+			DataType:    "->user",
+			Required:    true,
+			Description: "A natural person or the party user of a company",
 		},
 		{
 			Tag:      "timeout",
 			Label:    "Timeout",
 			DataType: "datetime",
 		},
+		{
+			Tag:         "quorums",
+			Label:       "Quorums",
+			DataType:    "[]@object",
+			Description: "Optional M-of-N rules over the required signers. The document is partially finalized when some quorums are met and finalized when every quorum is met and the signers outside quorums signed. e.g. [{\"name\": \"directors\", \"signers\": [d1, d2, d3], \"required\": 2}]",
+		},
+		{
+			Tag:         "version",
+			Label:       "Version",
+			DataType:    "number",
+			Description: "Version number of the document, starting at 1",
+		},
+		{
+			Tag:         "previousVersion",
+			Label:       "Previous Version",
+			DataType:    "->document",
+			Description: "Document this version amends",
+		},
+		{
+			Tag:         "previousHash",
+			Label:       "Previous Hash",
+			DataType:    "sha256",
+			Description: "originalHash of the document this version amends",
+		},
+		{
+			Tag:         "supersededBy",
+			Label:       "Superseded By",
+			DataType:    "->document",
+			Description: "Amendment that replaced this version. A superseded document can no longer be signed",
+		},
+		{
+			Tag:         "carriedOverSignatures",
+			Label:       "Carried Over Signatures",
+			DataType:    "[]->user",
+			Description: "Signers of the previous version still required, who may confirm this one regardless of the signing order",
+		},
+		{
+			Tag:         "rejectionPolicy",
+			Label:       "Rejection Policy",
+			DataType:    "string",
+			Description: "What happens when a signer rejects the document: cancel (default) or partiallyFinalize, which keeps the document and its signatures on record and lets the owner re-issue it by uploading it again",
+		},
+		{
+			Tag:         "rejections",
+			Label:       "Rejections",
+			DataType:    "[]@object",
+			Description: "Signer, reason and timestamp of each rejection",
+		},
 	},
 }
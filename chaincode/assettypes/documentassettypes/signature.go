@@ -0,0 +1,72 @@
+package documentassettypes
+
+import "github.com/hyperledger-labs/cc-tools/assets"
+
+// Signature is the proof that a signer agreed to a document: a signature over the document
+// originalHash, verified against the public key registered by the signer.
+var Signature = assets.AssetType{
+	Tag:         "signature",
+	Label:       "Signature",
+	Description: "Signature of a document by one of its signers",
+
+	Props: []assets.AssetProp{
+		{
+			Required: true,
+			IsKey:    true,
+			Tag:      "document",
+			Label:    "Document",
+			DataType: "->document",
+		},
+		{
+			Required: true,
+			IsKey:    true,
+			Tag:      "signer",
+			Label:    "Signer",
+			DataType: "->user",
+		},
+		{
+			Required:    true,
+			Tag:         "signature",
+			Label:       "Signature",
+			DataType:    "string",
+			Description: "Base64 encoded signature over the document originalHash",
+		},
+		{
+			Required:    true,
+			Tag:         "algorithm",
+			Label:       "Algorithm",
+			DataType:    "string",
+			Description: "ecdsa-sha256, ed25519, rsa-pkcs1v15-sha256 or rsa-pss-sha256",
+		},
+		{
+			Required:    true,
+			Tag:         "publicKey",
+			Label:       "Public Key",
+			DataType:    "pemPubKey",
+			Description: "Public key the signature was verified against, the delegate or representative key when they signed",
+		},
+		{
+			Tag:         "delegate",
+			Label:       "Delegate",
+			DataType:    "->user",
+			Description: "User who signed on behalf of the signer, with the key verified against",
+		},
+		{
+			Tag:      "delegation",
+			Label:    "Delegation",
+			DataType: "->delegation",
+		},
+		{
+			Tag:         "representative",
+			Label:       "Representative",
+			DataType:    "->user",
+			Description: "Legal representative who signed on behalf of a company signer, with the key verified against",
+		},
+		{
+			Required: true,
+			Tag:      "signedAt",
+			Label:    "Signed At",
+			DataType: "datetime",
+		},
+	},
+}
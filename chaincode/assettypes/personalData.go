@@ -0,0 +1,59 @@
+package assettypes
+
+import "github.com/hyperledger-labs/cc-tools/assets"
+
+// PersonalData holds the personal data of a user, kept off the world state so it can be erased.
+// The collection has a finite blockToLive so that, once erased, the data does not stay in the
+// private write sets of the peers. Renewing the personalData consent rewrites it before it expires.
+// Collections.json configuration is necessary
+var PersonalData = assets.AssetType{
+	Tag:         "personalData",
+	Label:       "Personal Data",
+	Description: "Personal data of a user",
+
+	Readers: []string{"org1MSP", "orgMSP"},
+	Props: []assets.AssetProp{
+		{
+			Required: true,
+			IsKey:    true,
+			Tag:      "user",
+			Label:    "User",
+			DataType: "->user",
+		},
+		{
+			Required: true,
+			Tag:      "cpf",
+			Label:    "cpf",
+			DataType: "cpf",
+		},
+		{
+			Required: true,
+			Tag:      "email",
+			Label:    "email",
+			DataType: "string",
+		},
+		{
+			Required: true,
+			Tag:      "name",
+			Label:    "name",
+			DataType: "string",
+		},
+		{
+			Required: true,
+			Tag:      "phone",
+			Label:    "phone",
+			DataType: "string",
+		},
+		{
+			Tag:      "userName",
+			Label:    "UserName",
+			DataType: "string",
+		},
+		{
+			Tag:         "legacyKey",
+			Label:       "Legacy Key",
+			DataType:    "string",
+			Description: "Ledger key the user had before migrateUsers issued it a new one, derived from the CPF and so kept only here",
+		},
+	},
+}
@@ -9,35 +9,59 @@ var User = assets.AssetType{
 
 	Props: []assets.AssetProp{
 		{
-			Required: true,
-			IsKey:    true,
-			Tag:      "cpf",
-			Label:    "cpf",
-			DataType: "cpf",
+			Required:    true,
+			IsKey:       true,
+			Tag:         "id",
+			Label:       "ID",
+			DataType:    "string",
+			Description: "Pseudonymous identifier, derived from the ID of the transaction that created or migrated the user. CPF, email, name, phone and user name are kept on the personalData collection",
 		},
 		{
-			Tag:      "email",
-			Label:    "email",
-			DataType: "string",
-			Required: true,
+			Tag:         "mspId",
+			Label:       "MSP ID",
+			DataType:    "string",
+			Description: "MSP of the client identity bound to the user",
 		},
 		{
-			Tag:      "name",
-			Label:    "name",
-			DataType: "string",
-			Required: true,
+			Tag:         "homeMspId",
+			Label:       "Home MSP ID",
+			DataType:    "string",
+			Description: "MSP of the organization that wrote a user created before identities were bound, recorded by migrateUsers. Only its admins can bind the user to a first identity",
 		},
 		{
-			Tag:      "phone",
-			Label:    "phone",
-			DataType: "string",
-			Required: true,
+			Tag:         "enrollmentId",
+			Label:       "Enrollment ID",
+			DataType:    "string",
+			Description: "Enrollment ID (certificate common name) of the client identity bound to the user",
 		},
 		{
-			Tag:      "userName",
-			Label:    "UserName",
-			DataType: "string",
-			Required: true,
+			Tag:         "certFingerprint",
+			Label:       "Certificate Fingerprint",
+			DataType:    "string",
+			Description: "Optional SHA-256 fingerprint of the client certificate. When set, only this certificate is accepted",
+		},
+		{
+			Tag:         "publicKey",
+			Label:       "Public Key",
+			DataType:    "pemPubKey",
+			Description: "Key used to verify the user signatures on documents",
+		},
+		{
+			Tag:         "company",
+			Label:       "Company",
+			DataType:    "->company",
+			Description: "Set on the party user of a company, whose legal representatives act on its behalf",
+		},
+		{
+			Tag:         "erased",
+			Label:       "Erased",
+			DataType:    "boolean",
+			Description: "Whether the personal data of the user was erased",
+		},
+		{
+			Tag:      "erasedAt",
+			Label:    "Erased At",
+			DataType: "datetime",
 		},
 	},
 }
@@ -16,6 +16,8 @@ const (
 	GetCredit
 	Payment
 	FinishContract
+	IndexAdjustment
+	Installments
 
 	NonExecutable ActionType = -1
 )
@@ -32,6 +34,10 @@ func (b ActionType) CheckType() errors.ICCError {
 		return nil
 	case FinishContract:
 		return nil
+	case IndexAdjustment:
+		return nil
+	case Installments:
+		return nil
 	case NonExecutable:
 		return nil
 	default:
@@ -48,6 +54,8 @@ var actionType = assets.DataType{
 		"get Credit":          GetCredit,
 		"payment":             Payment,
 		"finish contract":     FinishContract,
+		"index adjustment":    IndexAdjustment,
+		"installments":        Installments,
 		"non executable":      NonExecutable,
 	},
 	Description: "action type for clause",
@@ -75,3 +83,13 @@ var actionType = assets.DataType{
 		return fmt.Sprint(retVal), retVal, err
 	},
 }
+
+// ParseActionType reads the action type of a clause map, which is an ActionType once parsed by
+// cc-tools and a float64 when the clause is read back as raw JSON
+func ParseActionType(data interface{}) (ActionType, bool) {
+	_, value, err := actionType.Parse(data)
+	if err != nil {
+		return 0, false
+	}
+	return value.(ActionType), true
+}
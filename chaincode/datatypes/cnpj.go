@@ -0,0 +1,59 @@
+package datatypes
+
+import (
+	"strings"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+)
+
+var cnpj = assets.DataType{
+	AcceptedFormats: []string{"string"},
+	Parse: func(data interface{}) (string, interface{}, errors.ICCError) {
+		cnpj, ok := data.(string)
+		if !ok {
+			return "", nil, errors.NewCCError("property must be a string", 400)
+		}
+
+		cnpj = strings.ReplaceAll(cnpj, ".", "")
+		cnpj = strings.ReplaceAll(cnpj, "-", "")
+		cnpj = strings.ReplaceAll(cnpj, "/", "")
+
+		if len(cnpj) != 14 {
+			return "", nil, errors.NewCCError("CNPJ must have 14 digits", 400)
+		}
+
+		for _, d := range cnpj {
+			if d < '0' || d > '9' {
+				return "", nil, errors.NewCCError("CNPJ must have only digits", 400)
+			}
+		}
+
+		if cnpjCheckDigit(cnpj[:12]) != int(cnpj[12])-'0' {
+			return "", nil, errors.NewCCError("Invalid CNPJ", 400)
+		}
+		if cnpjCheckDigit(cnpj[:13]) != int(cnpj[13])-'0' {
+			return "", nil, errors.NewCCError("Invalid CNPJ", 400)
+		}
+
+		return cnpj, cnpj, nil
+	},
+}
+
+// cnpjCheckDigit computes the check digit of the CNPJ digits, weighted from 2 to 9 right to left
+func cnpjCheckDigit(digits string) int {
+	var sum int
+	weight := 2
+	for i := len(digits) - 1; i >= 0; i-- {
+		sum += (int(digits[i]) - '0') * weight
+		weight++
+		if weight > 9 {
+			weight = 2
+		}
+	}
+
+	if sum%11 < 2 {
+		return 0
+	}
+	return 11 - sum%11
+}
@@ -0,0 +1,59 @@
+package datatypes
+
+import "testing"
+
+func TestCNPJParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    string
+		wantErr bool
+	}{
+		{"formatted", "11.222.333/0001-81", "11222333000181", false},
+		{"digits only", "11222333000181", "11222333000181", false},
+		{"other formatted", "11.444.777/0001-61", "11444777000161", false},
+		{"first check digit zero", "10.000.008/0001-01", "10000008000101", false},
+		{"wrong first check digit", "11.222.333/0001-91", "", true},
+		{"wrong second check digit", "11.222.333/0001-82", "", true},
+		{"too short", "11.222.333/0001-8", "", true},
+		{"too long", "11.222.333/0001-811", "", true},
+		{"letters", "11.222.333/0001-8a", "", true},
+		{"spaces", "11 222 333 0001 81", "", true},
+		{"not a string", 11222333000181, "", true},
+	}
+
+	for _, tt := range tests {
+		key, value, err := cnpj.Parse(tt.value)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: Parse(%v) accepted an invalid CNPJ", tt.name, tt.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: Parse(%v) error = %v", tt.name, tt.value, err)
+			continue
+		}
+		if key != tt.want || value != tt.want {
+			t.Errorf("%s: Parse(%v) = %q, %v, want %q", tt.name, tt.value, key, value, tt.want)
+		}
+	}
+}
+
+func TestCNPJCheckDigit(t *testing.T) {
+	tests := []struct {
+		digits string
+		want   int
+	}{
+		{"112223330001", 8},
+		{"1122233300018", 1},
+		{"114447770001", 6},
+		{"1144477700016", 1},
+	}
+
+	for _, tt := range tests {
+		if got := cnpjCheckDigit(tt.digits); got != tt.want {
+			t.Errorf("cnpjCheckDigit(%q) = %d, want %d", tt.digits, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package datatypes
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+)
+
+type ContractStatusType float64
+
+const (
+	ContractDraft ContractStatusType = iota
+	ContractActive
+	ContractSuspended
+	ContractFinalized
+	ContractCancelled
+	ContractExpired
+	ContractPendingSignature
+)
+
+func (b ContractStatusType) CheckType() errors.ICCError {
+	switch b {
+	case ContractDraft:
+		return nil
+	case ContractActive:
+		return nil
+	case ContractSuspended:
+		return nil
+	case ContractFinalized:
+		return nil
+	case ContractCancelled:
+		return nil
+	case ContractExpired:
+		return nil
+	case ContractPendingSignature:
+		return nil
+	default:
+		return errors.NewCCError("invalid type", 400)
+	}
+
+}
+
+// IsTerminal tells whether the contract can no longer change status
+func (b ContractStatusType) IsTerminal() bool {
+	return b == ContractFinalized || b == ContractCancelled || b == ContractExpired
+}
+
+func (b ContractStatusType) String() string {
+	switch b {
+	case ContractDraft:
+		return "draft"
+	case ContractActive:
+		return "active"
+	case ContractSuspended:
+		return "suspended"
+	case ContractFinalized:
+		return "finalized"
+	case ContractCancelled:
+		return "cancelled"
+	case ContractExpired:
+		return "expired"
+	case ContractPendingSignature:
+		return "pending signature"
+	default:
+		return strconv.FormatFloat(float64(b), 'f', -1, 64)
+	}
+}
+
+var contractStatusType = assets.DataType{
+	AcceptedFormats: []string{"number"},
+	DropDownValues: map[string]interface{}{
+		"draft":             ContractDraft,
+		"active":            ContractActive,
+		"suspended":         ContractSuspended,
+		"finalized":         ContractFinalized,
+		"cancelled":         ContractCancelled,
+		"expired":           ContractExpired,
+		"pending signature": ContractPendingSignature,
+	},
+	Description: "Status of the contract",
+	Parse: func(data interface{}) (string, interface{}, errors.ICCError) {
+		var dataVal float64
+		switch v := data.(type) {
+		case float64:
+			dataVal = v
+		case int:
+			dataVal = (float64)(v)
+		case ContractStatusType:
+			dataVal = (float64)(v)
+		case string:
+			var err error
+			dataVal, err = strconv.ParseFloat(v, 64)
+			if err != nil {
+				return "", nil, errors.WrapErrorWithStatus(err, "asset property must be an integer, is %t", 400)
+			}
+		default:
+			return "", nil, errors.NewCCError("asset property must be an integer, is %t", 400)
+		}
+
+		retVal := (ContractStatusType)(dataVal)
+		err := retVal.CheckType()
+		return fmt.Sprint(float64(retVal)), retVal, err
+	},
+}
+
+// ParseContractStatus reads the status of a contract map, which is a ContractStatusType once
+// parsed by cc-tools and a float64 when the contract is read back as raw JSON
+func ParseContractStatus(data interface{}) (ContractStatusType, bool) {
+	_, value, err := contractStatusType.Parse(data)
+	if err != nil {
+		return 0, false
+	}
+	return value.(ContractStatusType), true
+}
@@ -6,10 +6,12 @@ import (
 
 // CustomDataTypes contain the user-defined primary data types
 var CustomDataTypes = map[string]assets.DataType{
-	"sha256":     Sha256,
-	"statusType": statusType,
-	"pemPubKey":  pemPubKey,
-	"cpf":        cpf,
-	"actionType": actionType,
-	"argDt":      argDt,
+	"sha256":         Sha256,
+	"statusType":     statusType,
+	"contractStatus": contractStatusType,
+	"pemPubKey":      pemPubKey,
+	"cpf":            cpf,
+	"cnpj":           cnpj,
+	"actionType":     actionType,
+	"argDt":          argDt,
 }
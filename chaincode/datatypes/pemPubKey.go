@@ -1,13 +1,19 @@
 package datatypes
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+
 	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/errors"
 )
 
 var pemPubKey = assets.DataType{
 	AcceptedFormats: []string{"string"},
-	Description:     "Pem encoded public key",
+	Description:     "Pem encoded ECDSA, Ed25519 or RSA public key",
 	Parse: func(data interface{}) (string, interface{}, errors.ICCError) {
 		pubKey, ok := data.(string)
 		if !ok {
@@ -15,11 +21,31 @@ var pemPubKey = assets.DataType{
 		}
 
 		// Validates public key format
-		// block, _ := pem.Decode([]byte(pubKey))
-		// if block == nil || block.Type != "PUBLIC KEY" {
-		// 	return "", nil, errors.NewCCError("The key format is not valid", 400)
-		// }
+		_, err := ParsePublicKey(pubKey)
+		if err != nil {
+			return "", nil, err
+		}
 
 		return pubKey, pubKey, nil
 	},
 }
+
+// ParsePublicKey decodes a PEM encoded PKIX public key of one of the supported signature algorithms
+func ParsePublicKey(pubKey string) (interface{}, errors.ICCError) {
+	block, _ := pem.Decode([]byte(pubKey))
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, errors.NewCCError("The key format is not valid", 400)
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.WrapErrorWithStatus(err, "The key format is not valid", 400)
+	}
+
+	switch key.(type) {
+	case *ecdsa.PublicKey, ed25519.PublicKey, *rsa.PublicKey:
+		return key, nil
+	default:
+		return nil, errors.NewCCError("The key must be an ECDSA, Ed25519 or RSA key", 400)
+	}
+}
@@ -70,3 +70,13 @@ var statusType = assets.DataType{
 		return fmt.Sprint(retVal), retVal, err
 	},
 }
+
+// ParseStatus reads the status of a document map, which is a StatusType once parsed by
+// cc-tools and a float64 when the document is read back as raw JSON
+func ParseStatus(data interface{}) (StatusType, bool) {
+	_, value, err := statusType.Parse(data)
+	if err != nil {
+		return 0, false
+	}
+	return value.(StatusType), true
+}
@@ -2,6 +2,24 @@ package main
 
 import (
 	"github.com/hyperledger-labs/cc-tools/events"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/eventtypes"
 )
 
-var eventTypeList = []events.Event{}
+var eventTypeList = []events.Event{
+	eventtypes.ContractCreated,
+	eventtypes.ClauseExecuted,
+	eventtypes.ClauseFinalized,
+	eventtypes.ContractFinalized,
+	eventtypes.ContractCancelled,
+	eventtypes.ContractStatusChanged,
+	eventtypes.ContractAmended,
+
+	eventtypes.DocumentUploaded,
+	eventtypes.SignatureAdded,
+	eventtypes.DocumentSigned,
+	eventtypes.DocumentExpired,
+	eventtypes.DocumentsExpired,
+	eventtypes.SignatureRejected,
+	eventtypes.DocumentAmended,
+	eventtypes.DocumentCancelled,
+}
@@ -0,0 +1,59 @@
+package eventtypes
+
+import "github.com/hyperledger-labs/cc-tools/events"
+
+var ContractCreated = events.Event{
+	Tag:         "contractCreated",
+	Label:       "Contract Created",
+	Description: "An auto executable contract was created",
+	Type:        events.EventLog,
+	BaseLog:     "Contract created",
+}
+
+var ClauseExecuted = events.Event{
+	Tag:         "clauseExecuted",
+	Label:       "Clause Executed",
+	Description: "Clauses of a contract were executed and none was finalized",
+	Type:        events.EventLog,
+	BaseLog:     "Clauses executed",
+}
+
+var ClauseFinalized = events.Event{
+	Tag:         "clauseFinalized",
+	Label:       "Clause Finalized",
+	Description: "Clauses of a contract were executed and at least one was finalized",
+	Type:        events.EventLog,
+	BaseLog:     "Clauses finalized",
+}
+
+var ContractFinalized = events.Event{
+	Tag:         "contractFinalized",
+	Label:       "Contract Finalized",
+	Description: "The finish contract clause of a contract finalized it",
+	Type:        events.EventLog,
+	BaseLog:     "Contract finalized",
+}
+
+var ContractCancelled = events.Event{
+	Tag:         "contractCancelled",
+	Label:       "Contract Cancelled",
+	Description: "The finish contract clause of a contract cancelled it",
+	Type:        events.EventLog,
+	BaseLog:     "Contract cancelled",
+}
+
+var ContractStatusChanged = events.Event{
+	Tag:         "contractStatusChanged",
+	Label:       "Contract Status Changed",
+	Description: "A contract was activated, suspended, expired or otherwise moved to a new status",
+	Type:        events.EventLog,
+	BaseLog:     "Contract status changed",
+}
+
+var ContractAmended = events.Event{
+	Tag:         "contractAmended",
+	Label:       "Contract Amended",
+	Description: "Every party approved an amendment and it was applied to the contract. Carries the new status when the amendment changed it",
+	Type:        events.EventLog,
+	BaseLog:     "Contract amended",
+}
@@ -0,0 +1,67 @@
+package eventtypes
+
+import "github.com/hyperledger-labs/cc-tools/events"
+
+var DocumentUploaded = events.Event{
+	Tag:         "documentUploaded",
+	Label:       "Document Uploaded",
+	Description: "A document was uploaded and is waiting for signatures",
+	Type:        events.EventLog,
+	BaseLog:     "Document uploaded",
+}
+
+var SignatureAdded = events.Event{
+	Tag:         "signatureAdded",
+	Label:       "Signature Added",
+	Description: "A required signer signed a document that still needs other signatures",
+	Type:        events.EventLog,
+	BaseLog:     "Signature added",
+}
+
+var DocumentSigned = events.Event{
+	Tag:         "documentSigned",
+	Label:       "Document Signed",
+	Description: "The last required signature of a document was added",
+	Type:        events.EventLog,
+	BaseLog:     "Document fully signed",
+}
+
+var DocumentExpired = events.Event{
+	Tag:         "documentExpired",
+	Label:       "Document Expired",
+	Description: "A document expired before being fully signed",
+	Type:        events.EventLog,
+	BaseLog:     "Document expired",
+}
+
+var DocumentsExpired = events.Event{
+	Tag:         "documentsExpired",
+	Label:       "Documents Expired",
+	Description: "Waiting documents past their timeout were expired in bulk. Data holds the list of expired documents",
+	Type:        events.EventLog,
+	BaseLog:     "Documents expired",
+}
+
+var SignatureRejected = events.Event{
+	Tag:         "signatureRejected",
+	Label:       "Signature Rejected",
+	Description: "A required signer rejected a document, which was cancelled or partially finalized according to its rejection policy",
+	Type:        events.EventLog,
+	BaseLog:     "Signature rejected",
+}
+
+var DocumentAmended = events.Event{
+	Tag:         "documentAmended",
+	Label:       "Document Amended",
+	Description: "A new version of a document was created. Data holds the new version",
+	Type:        events.EventLog,
+	BaseLog:     "Document amended",
+}
+
+var DocumentCancelled = events.Event{
+	Tag:         "documentCancelled",
+	Label:       "Document Cancelled",
+	Description: "A document was cancelled",
+	Type:        events.EventLog,
+	BaseLog:     "Document cancelled",
+}
@@ -0,0 +1,67 @@
+package eventtypes
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+)
+
+// Payload is the JSON payload of every event. Data holds one of the event structs below.
+type Payload struct {
+	Event     string      `json:"event"`
+	TxId      string      `json:"txId"`
+	Timestamp string      `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+type ContractEvent struct {
+	Contract string        `json:"contract"`
+	Name     string        `json:"name,omitempty"`
+	Status   string        `json:"status,omitempty"`
+	Clauses  []ClauseEvent `json:"clauses,omitempty"`
+}
+
+type ClauseEvent struct {
+	Clause    string `json:"clause"`
+	Id        string `json:"id"`
+	Success   bool   `json:"success"`
+	Feedback  string `json:"feedback"`
+	Finalized bool   `json:"finalized"`
+	Skipped   bool   `json:"skipped,omitempty"` // the clause was not executed, Feedback tells why
+}
+
+type DocumentEvent struct {
+	Document           string  `json:"document"`
+	Name               string  `json:"name,omitempty"`
+	Status             float64 `json:"status"`
+	Signer             string  `json:"signer,omitempty"`
+	Delegate           string  `json:"delegate,omitempty"`
+	Reason             string  `json:"reason,omitempty"`
+	Signatures         int     `json:"signatures"`
+	RequiredSignatures int     `json:"requiredSignatures"`
+}
+
+// Emit sets the event of the transaction.
+// Fabric delivers a single event per transaction, the last one set, so a transaction that
+// goes through several lifecycle steps emits only the most significant one with all the details in data.
+func Emit(stub *sw.StubWrapper, event events.Event, data interface{}) errors.ICCError {
+	txTimestamp, err := stub.Stub.GetTxTimestamp()
+	if err != nil {
+		return errors.WrapError(err, "Failed to get transaction timestamp")
+	}
+
+	payload, err := json.Marshal(Payload{
+		Event:     event.Tag,
+		TxId:      stub.Stub.GetTxID(),
+		Timestamp: time.Unix(txTimestamp.Seconds, int64(txTimestamp.Nanos)).UTC().Format(time.RFC3339),
+		Data:      data,
+	})
+	if err != nil {
+		return errors.WrapError(err, "Failed to marshal event payload")
+	}
+
+	return event.CallEvent(stub, payload)
+}
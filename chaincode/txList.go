@@ -6,25 +6,39 @@ import (
 	"github.com/hyperledger-labs/clausia-cc/chaincode/txdefs/document"
 )
 
+// The generic asset transactions of cc-tools are not registered: every asset type is written
+// only by the transactions that check its callers and keep its invariants
 var txList = []tx.Transaction{
-
-	tx.CreateAsset,
-	tx.UpdateAsset,
-	tx.DeleteAsset,
-
 	document.CancelDocument,
 	document.UploadDocument,
 	document.PutSignature,
+	document.RejectSignature,
 	document.CreateSigner,
 	document.GetDoc,
 	document.GetUserKey,
 	document.GetSigner,
 	document.GetExpiredDoc,
+	document.ExpireDocuments,
 	document.UpdateDocument,
 	document.UpdateSigner,
+	document.BindUserIdentity,
+	document.GetPersonalData,
+	document.GrantConsent,
+	document.RevokeConsent,
+	document.EraseUser,
+	document.MigrateUsers,
+	document.CreateCompany,
+	document.UpdateCompany,
+	document.RegisterPublicKey,
+	document.GrantDelegation,
+	document.RevokeDelegation,
 	document.ExpectedUserDoc,
+	document.GetExpectedSigners,
 	document.GetDocHistory,
+	document.AmendDocument,
+	document.GetDocumentVersions,
 	document.SearchAssetQuery,
+	document.SearchDocuments,
 
 	contract.CreateAutoExecutableContract,
 	contract.AddClause,
@@ -34,17 +48,29 @@ var txList = []tx.Transaction{
 	contract.AddReferenceDateCDI,
 	contract.AddEvalutedDateCDI,
 	contract.ContractsWithExecutableClauses,
+	contract.SearchContracts,
 	contract.ExecuteAutoExecutableContract,
+	contract.SimulateContractExecution,
+	contract.GetClauseExecutions,
 	contract.AddInputToCheckFineClause,
 	contract.AddStoredValueToGetCredit,
 	contract.AddReviewToContract,
 	contract.AddInputsToMakePaymentClause,
+	contract.VerifyPaymentDetails,
+	contract.MigratePaymentDetails,
+	contract.AddInstallmentPayment,
 	contract.CancelContract,
+	contract.UpdateContractStatus,
+	contract.ProposeAmendment,
+	contract.ApproveAmendment,
+	contract.RejectAmendment,
 	contract.CreateTemplate,
 	contract.CreateTemplateClause,
 	contract.EditTemplate,
 	contract.EditTemplateClause,
 	contract.DuplicateTemplate,
+	contract.CreateContractFromTemplate,
 	contract.RemoveTemplate,
 	contract.RemoveTemplateClause,
+	contract.PublishIndexRates,
 }
@@ -67,129 +67,167 @@ var AddClause = tx.Transaction{
 		},
 	},
 	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
-		actionTypeFloat, ok := req["actionType"].(datatypes.ActionType)
+		contractKey, ok := req["autoExecutableContract"].(assets.Key)
 		if !ok {
-			return nil, errors.WrapError(nil, "Invalid type for actionType")
+			return nil, errors.WrapError(nil, "Parameter 'autoExecutableContract' must be an asset key")
 		}
-		actionType := datatypes.ActionType(actionTypeFloat)
 
-		paramHandler := params.Get(actionType)
-
-		clause := map[string]interface{}{
-			"@assetType": "clause",
-			"id":         req["id"],
-			"actionType": actionType,
-			"executable": true,
-			"finalized":  false,
+		contract, err := contractKey.Get(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get autoExecutableContract asset from ledger")
 		}
 
-		if description, ok := req["description"].(string); ok {
-			clause["description"] = description
-		}
-		if category, ok := req["category"].(string); ok {
-			clause["category"] = category
+		err = checkOwner(stub, *contract)
+		if err != nil {
+			return nil, err
 		}
 
-		if actionType == datatypes.NonExecutable {
-			clause["executable"] = false
+		err = checkDirectEdit(*contract)
+		if err != nil {
+			return nil, err
 		}
 
-		filteredInput := make(map[string]interface{})
-		if input, ok := req["input"].(map[string]interface{}); ok {
-			formatOutputNames(input)
-			inputType := paramHandler.GetInputs()
-			filteredInput = filterFields(input, inputType)
-			clause["input"] = filteredInput
-		}
+		return addClause(stub, req)
+	},
+}
 
-		filteredParams := make(map[string]interface{})
-		if parameters, ok := req["parameters"].(map[string]interface{}); ok {
-			formatOutputNames(parameters)
-			paramsType := paramHandler.GetParameters()
-			filteredParams = filterFields(parameters, paramsType)
-			clause["parameters"] = filteredParams
-		}
+// addClause creates the clause and adds it to the contract. Callers check who may change the contract.
+func addClause(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+	actionTypeFloat, ok := req["actionType"].(datatypes.ActionType)
+	if !ok {
+		return nil, errors.WrapError(nil, "Invalid type for actionType")
+	}
+	actionType := datatypes.ActionType(actionTypeFloat)
 
-		if actionType == datatypes.NonExecutable {
-			clause["executable"] = false
-		}
+	paramHandler := params.Get(actionType)
 
-		contractKey, ok := req["autoExecutableContract"].(assets.Key)
-		if !ok {
-			return nil, errors.WrapError(nil, "Parameter 'autoExecutableContract' must be an asset key")
-		}
+	clause := map[string]interface{}{
+		"@assetType": "clause",
+		"id":         req["id"],
+		"actionType": actionType,
+		"executable": true,
+		"finalized":  false,
+	}
 
-		contract, err := contractKey.Get(stub)
-		if err != nil {
-			return nil, errors.WrapError(err, "Failed to get autoExecutableContract asset from ledger")
-		}
+	if description, ok := req["description"].(string); ok {
+		clause["description"] = description
+	}
+	if category, ok := req["category"].(string); ok {
+		clause["category"] = category
+	}
 
-		contractDates, ok := (*contract)["dates"].(map[string]interface{})
-		if !ok {
-			contractDates = make(map[string]interface{})
-		}
+	if actionType == datatypes.NonExecutable {
+		clause["executable"] = false
+	}
 
-		clauses, exists := (*contract)["clauses"].([]interface{})
-		if !exists {
-			clauses = make([]interface{}, 0)
-		}
-		mapOfCurrClauses := utils.GenMapOfCurrClauses(clauses)
+	filteredInput := make(map[string]interface{})
+	if input, ok := req["input"].(map[string]interface{}); ok && paramHandler != nil {
+		formatOutputNames(input)
+		inputType := paramHandler.GetInputs()
+		filteredInput = filterFields(input, inputType)
+		clause["input"] = filteredInput
+	}
 
-		if dependencies, ok := req["dependencies"].([]interface{}); ok {
+	filteredParams := make(map[string]interface{})
+	if parameters, ok := req["parameters"].(map[string]interface{}); ok && paramHandler != nil {
+		formatOutputNames(parameters)
+		paramsType := paramHandler.GetParameters()
+		filteredParams = filterFields(parameters, paramsType)
+		clause["parameters"] = filteredParams
+	}
 
-			removedUnexistingClause, err := utils.RemoveUnexisting(dependencies, mapOfCurrClauses, stub)
-			if err != nil {
-				return nil, errors.WrapError(err, "failed to removed unexisting clauses")
-			}
-			clause["dependencies"] = removedUnexistingClause
-		}
+	if actionType == datatypes.NonExecutable {
+		clause["executable"] = false
+	}
 
-		newClause, err := assets.NewAsset(clause)
-		if err != nil {
-			return nil, errors.WrapError(err, "Failed to create clause asset")
-		}
+	contractKey, ok := req["autoExecutableContract"].(assets.Key)
+	if !ok {
+		return nil, errors.WrapError(nil, "Parameter 'autoExecutableContract' must be an asset key")
+	}
+
+	contract, err := contractKey.Get(stub)
+	if err != nil {
+		return nil, errors.WrapError(err, "Failed to get autoExecutableContract asset from ledger")
+	}
+
+	err = checkEditable(*contract)
+	if err != nil {
+		return nil, err
+	}
+
+	contractDates, ok := (*contract)["dates"].(map[string]interface{})
+	if !ok {
+		contractDates = make(map[string]interface{})
+	}
+
+	clauses, exists := (*contract)["clauses"].([]interface{})
+	if !exists {
+		clauses = make([]interface{}, 0)
+	}
+	mapOfCurrClauses := utils.GenMapOfCurrClauses(clauses)
 
-		clauseAsset, err := newClause.PutNew(stub)
+	if dependencies, ok := req["dependencies"].([]interface{}); ok {
+		// A new clause can only depend on clauses already in the contract, so it cannot close a cycle
+		checkedDependencies, err := utils.CheckDependencies(dependencies, mapOfCurrClauses)
 		if err != nil {
-			return nil, errors.WrapError(err, "Failed to save clause asset on ledger")
+			return nil, errors.WrapError(err, "Invalid clause dependencies")
 		}
+		clause["dependencies"] = checkedDependencies
+	}
 
-		clauses = append(clauses, clauseAsset)
+	newClause, err := assets.NewAsset(clause)
+	if err != nil {
+		return nil, errors.WrapError(err, "Failed to create clause asset")
+	}
+
+	clauseAsset, err := newClause.PutNew(stub)
+	if err != nil {
+		return nil, errors.WrapError(err, "Failed to save clause asset on ledger")
+	}
 
-		extractDates := ExtractDates(filteredParams, filteredInput)
+	clauses = append(clauses, clauseAsset)
 
-		for k, v := range extractDates {
-			newKey := k
-			i := 1
-			for {
-				if _, exists := contractDates[newKey]; !exists {
-					break
-				}
-				newKey = fmt.Sprintf("%s_%d", k, i)
-				i++
+	extractDates := ExtractDates(filteredParams, filteredInput)
+
+	for k, v := range extractDates {
+		newKey := k
+		i := 1
+		for {
+			if _, exists := contractDates[newKey]; !exists {
+				break
 			}
-			contractDates[newKey] = v
+			newKey = fmt.Sprintf("%s_%d", k, i)
+			i++
 		}
+		contractDates[newKey] = v
+	}
 
-		updatedContract, err := contractKey.Update(stub, map[string]interface{}{
-			"clauses": clauses,
-			"dates":   contractDates,
-		})
-		if err != nil {
-			return nil, errors.WrapError(err, "Failed to update contract asset with new clause")
-		}
+	updatedContract, err := contractKey.Update(stub, map[string]interface{}{
+		"clauses": clauses,
+		"dates":   contractDates,
+	})
+	if err != nil {
+		return nil, errors.WrapError(err, "Failed to update contract asset with new clause")
+	}
 
-		responseJSON, nerr := json.Marshal(updatedContract)
-		if nerr != nil {
-			return nil, errors.WrapError(err, "Failed to marshal response to JSON format")
-		}
+	responseJSON, nerr := json.Marshal(updatedContract)
+	if nerr != nil {
+		return nil, errors.WrapError(err, "Failed to marshal response to JSON format")
+	}
 
-		return responseJSON, nil
-	},
+	return responseJSON, nil
+}
+
+// rawStringFields are not output names and keep their value as given
+var rawStringFields = map[string]bool{
+	"timezone": true,
 }
 
 func formatOutputNames(params map[string]interface{}) {
 	for key, value := range params {
+		if rawStringFields[key] {
+			continue
+		}
 		if strValue, ok := value.(string); ok {
 			params[key] = utils.ValidateAndCleanData(strValue)
 		}
@@ -2,11 +2,15 @@ package contract
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/http"
 
 	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/errors"
 	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
 	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
 )
 
 var AddClauses = tx.Transaction{
@@ -40,42 +44,28 @@ var AddClauses = tx.Transaction{
 			return nil, errors.WrapError(nil, "Parameter 'clauses' must be a list of clauses")
 		}
 
-		for _, item := range clauseList {
-			clauseMap, ok := item.(map[string]interface{})
-			if !ok {
-				return nil, errors.WrapError(nil, "Each clause must be an object")
-			}
+		contract, err := contractKey.Get(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get autoExecutableContract asset from ledger")
+		}
 
-			args := map[string]interface{}{
-				"autoExecutableContract": contractKey,
-				"id":                     clauseMap["id"],
-				"actionType":             clauseMap["actionType"],
-			}
+		err = checkOwner(stub, *contract)
+		if err != nil {
+			return nil, err
+		}
 
-			if description, ok := clauseMap["description"].(string); ok {
-				args["description"] = description
-			}
-			if category, ok := clauseMap["category"].(string); ok {
-				args["category"] = category
-			}
-			if parameters, ok := clauseMap["parameters"].(map[string]interface{}); ok {
-				args["parameters"] = parameters
-			}
-			if input, ok := clauseMap["input"].(map[string]interface{}); ok {
-				args["input"] = input
-			}
-			if dependencies, ok := clauseMap["dependencies"].([]interface{}); ok {
-				args["dependencies"] = dependencies
-			}
+		err = checkDirectEdit(*contract)
+		if err != nil {
+			return nil, err
+		}
 
-			_, err := AddClause.Routine(stub, args)
-			if err != nil {
-				return nil, errors.WrapError(err, "Failed to add clause")
-			}
+		err = addClauses(stub, contractKey, clauseList)
+		if err != nil {
+			return nil, err
 		}
 
 		// Get the final state of the contract
-		contract, err := contractKey.Get(stub)
+		contract, err = contractKey.Get(stub)
 		if err != nil {
 			return nil, errors.WrapError(err, "Failed to get updated contract asset from ledger")
 		}
@@ -83,9 +73,119 @@ var AddClauses = tx.Transaction{
 		// Return the end state of the contract
 		responseJSON, nerr := json.Marshal(contract)
 		if nerr != nil {
-			return nil, errors.WrapError(err, "Failed to marshal response to JSON format")
+			return nil, errors.WrapError(nerr, "Failed to marshal response to JSON format")
 		}
 
 		return responseJSON, nil
 	},
 }
+
+// addClauses adds the listed clauses to the contract. Callers check who may change the contract.
+func addClauses(stub *sw.StubWrapper, contractKey assets.Key, clauseList []interface{}) errors.ICCError {
+	// Clauses in the list may depend on each other, so they are added in dependency order
+	var order []string
+	argsByKey := make(map[string]map[string]interface{})
+	dependencyKeys := make(map[string][]string)
+	for _, item := range clauseList {
+		clauseMap, ok := item.(map[string]interface{})
+		if !ok {
+			return errors.WrapError(nil, "Each clause must be an object")
+		}
+
+		id, ok := clauseMap["id"].(string)
+		if !ok {
+			return errors.NewCCError("Each clause must have an id", http.StatusBadRequest)
+		}
+
+		actionTypeValue, ok := clauseMap["actionType"].(float64)
+		if !ok {
+			return errors.NewCCError(fmt.Sprintf("Clause %s must have a numeric action type", id), http.StatusBadRequest)
+		}
+		actionType := datatypes.ActionType(actionTypeValue)
+		err := actionType.CheckType()
+		if err != nil {
+			return errors.WrapError(err, fmt.Sprintf("Invalid action type on clause %s", id))
+		}
+
+		clauseKey, err := assets.NewKey(map[string]interface{}{
+			"@assetType": "clause",
+			"id":         id,
+		})
+		if err != nil {
+			return errors.WrapError(err, "Failed to generate clause key")
+		}
+		if _, exists := argsByKey[clauseKey.Key()]; exists {
+			return errors.NewCCError(fmt.Sprintf("Clause %s is duplicated", id), http.StatusBadRequest)
+		}
+
+		args := map[string]interface{}{
+			"autoExecutableContract": contractKey,
+			"id":                     id,
+			"actionType":             actionType,
+		}
+
+		if description, ok := clauseMap["description"].(string); ok {
+			args["description"] = description
+		}
+		if category, ok := clauseMap["category"].(string); ok {
+			args["category"] = category
+		}
+		if parameters, ok := clauseMap["parameters"].(map[string]interface{}); ok {
+			args["parameters"] = parameters
+		}
+		if input, ok := clauseMap["input"].(map[string]interface{}); ok {
+			args["input"] = input
+		}
+		if dependencies, ok := clauseMap["dependencies"].([]interface{}); ok {
+			var depKeys []interface{}
+			for _, dep := range dependencies {
+				depKey, err := dependencyKey(dep)
+				if err != nil {
+					return errors.WrapError(err, fmt.Sprintf("Invalid dependency on clause %s", id))
+				}
+				depKeys = append(depKeys, depKey)
+				dependencyKeys[clauseKey.Key()] = append(dependencyKeys[clauseKey.Key()], depKey.Key())
+			}
+			args["dependencies"] = depKeys
+		}
+
+		order = append(order, clauseKey.Key())
+		argsByKey[clauseKey.Key()] = args
+	}
+
+	sorted, cyclic := utils.SortDependencies(order, dependencyKeys)
+	if len(cyclic) > 0 {
+		var cyclicIds []interface{}
+		for _, key := range cyclic {
+			cyclicIds = append(cyclicIds, argsByKey[key]["id"])
+		}
+		return errors.NewCCError(fmt.Sprintf("Clauses have cyclic dependencies: %v", cyclicIds), http.StatusBadRequest)
+	}
+
+	for _, key := range sorted {
+		_, err := addClause(stub, argsByKey[key])
+		if err != nil {
+			return errors.WrapError(err, fmt.Sprintf("Failed to add clause %s", argsByKey[key]["id"]))
+		}
+	}
+
+	return nil
+}
+
+// dependencyKey accepts a clause key object or the id of a clause
+func dependencyKey(dep interface{}) (assets.Key, errors.ICCError) {
+	switch d := dep.(type) {
+	case string:
+		return assets.NewKey(map[string]interface{}{
+			"@assetType": "clause",
+			"id":         d,
+		})
+	case map[string]interface{}:
+		if _, ok := d["@assetType"]; !ok {
+			d["@assetType"] = "clause"
+		}
+		return assets.NewKey(d)
+	default:
+		return nil, errors.NewCCError("Dependency must be a clause key or a clause id", http.StatusBadRequest)
+	}
+}
@@ -81,6 +81,13 @@ var AddEvalutedDateCDI = tx.Transaction{
 			return nil, errors.WrapError(err, "Failed to get autoExecutableContract asset from ledger")
 		}
 
+		if err := checkParticipant(stub, *contractAsset); err != nil {
+			return nil, err
+		}
+		if err := checkActive(stub, *contractAsset); err != nil {
+			return nil, err
+		}
+
 		actionType, ok := (*clauseAsset)["actionType"].(datatypes.ActionType)
 		if !ok {
 			return nil, errors.NewCCError("Invalid action type format", 400)
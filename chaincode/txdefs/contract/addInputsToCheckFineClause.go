@@ -60,6 +60,21 @@ var AddInputToCheckFineClause = tx.Transaction{
 			return nil, errors.WrapError(err, "Failed to get clause asset from ledger")
 		}
 
+		contractAsset, err := getClauseContract(stub, clauseKey)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get clause contract")
+		}
+
+		err = checkParticipant(stub, *contractAsset)
+		if err != nil {
+			return nil, err
+		}
+
+		err = checkActive(stub, *contractAsset)
+		if err != nil {
+			return nil, err
+		}
+
 		actionType, ok := (*clauseAsset)["actionType"].(datatypes.ActionType)
 		if !ok {
 			return nil, errors.NewCCError("Invalid action type format", 400)
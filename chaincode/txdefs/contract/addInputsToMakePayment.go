@@ -14,10 +14,10 @@ import (
 var AddInputsToMakePaymentClause = tx.Transaction{
 	Tag:         "addInputsToMakePaymentClause",
 	Label:       "Add Input To make payment Clause",
-	Description: "",
+	Description: "Adds the payment inputs to a payment clause. The receipt URL and payment provider identifiers must be sent through the transient map",
 	Method:      "POST",
 
-	Args: []tx.Argument{
+	Args: append([]tx.Argument{
 		{
 			Required: true,
 			Tag:      "clause",
@@ -47,22 +47,7 @@ var AddInputsToMakePaymentClause = tx.Transaction{
 			DataType: "boolean",
 			Required: true,
 		},
-		{
-			Tag:      "receiptUrl",
-			Label:    "receipt url",
-			DataType: "string",
-		},
-		{
-			Tag:      "stripeToken",
-			Label:    "Stripe Token",
-			DataType: "string",
-		},
-		{
-			Tag:      "payPalTransactionID",
-			Label:    "PayPal Transaction ID",
-			DataType: "string",
-		},
-	},
+	}, paymentDetailsArgs...),
 	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
 		clauseKey, ok := req["clause"].(assets.Key)
 		if !ok {
@@ -96,6 +81,16 @@ var AddInputsToMakePaymentClause = tx.Transaction{
 			return nil, errors.WrapError(err, "Failed to get autoExecutableContract asset from ledger")
 		}
 
+		err = checkParticipant(stub, *contractAsset)
+		if err != nil {
+			return nil, err
+		}
+
+		err = checkActive(stub, *contractAsset)
+		if err != nil {
+			return nil, err
+		}
+
 		clauseAsset, err := clauseKey.Get(stub)
 		if err != nil {
 			return nil, errors.WrapError(err, "Failed to get clause asset from ledger")
@@ -131,15 +126,18 @@ var AddInputsToMakePaymentClause = tx.Transaction{
 				input["receiptHash"] = hash
 			}
 
-			if req["receiptUrl"] != nil {
-				input["receiptUrl"] = req["receiptUrl"]
+			// The payment details are only kept on the private collection
+			for _, field := range paymentDetailsFields {
+				delete(input, field)
 			}
+			delete(input, "detailsHash")
 
-			if req["stripeToken"] != nil {
-				input["stripeToken"] = req["stripeToken"]
+			detailsHash, err := putPaymentDetails(stub, *contractAsset, clauseKey, req)
+			if err != nil {
+				return nil, err
 			}
-			if req["payPalTransactionID"] != nil {
-				input["payPalTransactionID"] = req["payPalTransactionID"]
+			if detailsHash != "" {
+				input["detailsHash"] = detailsHash
 			}
 
 			clauseUpdated, err := clauseAsset.Update(stub, map[string]interface{}{
@@ -0,0 +1,139 @@
+package contract
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
+)
+
+var AddInstallmentPayment = tx.Transaction{
+	Tag:         "addInstallmentPayment",
+	Label:       "Add Installment Payment",
+	Description: "Registers a payment on an installments clause. The payment is allocated on the next contract execution. The receipt URL must be sent through the transient map",
+	Method:      "POST",
+
+	Args: append([]tx.Argument{
+		{
+			Required: true,
+			Tag:      "clause",
+			Label:    "Clause",
+			DataType: "->clause",
+		},
+		{
+			Required: true,
+			Tag:      "date",
+			Label:    "Date",
+			DataType: "datetime",
+		},
+		{
+			Required: true,
+			Tag:      "amount",
+			Label:    "Amount",
+			DataType: "number",
+		},
+		{
+			Tag:         "installment",
+			Label:       "Installment",
+			Description: "Number of the installment being paid. Defaults to the oldest open installment",
+			DataType:    "number",
+		},
+		{
+			Tag:      "receiptHash",
+			Label:    "Receipt hash",
+			DataType: "string",
+		},
+	}, paymentDetailsArgs...),
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		clauseKey, ok := req["clause"].(assets.Key)
+		if !ok {
+			return nil, errors.NewCCError("Invalid clause format", http.StatusBadRequest)
+		}
+
+		amount, _ := req["amount"].(float64)
+		if amount <= 0 {
+			return nil, errors.NewCCError("Payment amount must be greater than zero", http.StatusBadRequest)
+		}
+
+		clauseAsset, err := clauseKey.Get(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get clause asset from ledger")
+		}
+
+		contractAsset, err := getClauseContract(stub, clauseKey)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get clause contract")
+		}
+
+		err = checkParticipant(stub, *contractAsset)
+		if err != nil {
+			return nil, err
+		}
+
+		err = checkActive(stub, *contractAsset)
+		if err != nil {
+			return nil, err
+		}
+
+		actionType, ok := datatypes.ParseActionType((*clauseAsset)["actionType"])
+		if !ok || actionType != datatypes.Installments {
+			return nil, errors.NewCCError("Action type is not installments", http.StatusBadRequest)
+		}
+
+		if finalized, _ := (*clauseAsset)["finalized"].(bool); finalized {
+			return nil, errors.NewCCError("All installments of the clause are already paid", http.StatusBadRequest)
+		}
+
+		payment := map[string]interface{}{
+			"date":   req["date"],
+			"amount": amount,
+		}
+
+		if installment, ok := req["installment"].(float64); ok {
+			payment["installment"] = installment
+		}
+
+		if req["receiptHash"] != nil {
+			hash, _, err := datatypes.Sha256.Parse(req["receiptHash"])
+			if err != nil {
+				return nil, errors.WrapError(err, "Invalid receipt hash")
+			}
+			payment["receiptHash"] = hash
+		}
+
+		// The receipt URL is only kept on the private collection
+		detailsHash, err := putPaymentDetails(stub, *contractAsset, clauseKey, req)
+		if err != nil {
+			return nil, err
+		}
+		if detailsHash != "" {
+			payment["detailsHash"] = detailsHash
+		}
+
+		input, ok := (*clauseAsset)["input"].(map[string]interface{})
+		if !ok {
+			input = make(map[string]interface{})
+		}
+
+		payments, _ := input["payments"].([]interface{})
+		input["payments"] = append(payments, payment)
+
+		clauseUpdated, err := clauseAsset.Update(stub, map[string]interface{}{
+			"input": input,
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to update clause")
+		}
+
+		response, nerr := json.Marshal(clauseUpdated)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "Failed to marshal updated clause")
+		}
+
+		return response, nil
+	},
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/hyperledger-labs/cc-tools/errors"
 	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
 	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
 )
 
 var AddParticipants = tx.Transaction{
@@ -45,10 +46,27 @@ var AddParticipants = tx.Transaction{
 			return nil, errors.WrapError(err, "Failed to get autoExecutableContract asset from ledger")
 		}
 
+		err = checkOwner(stub, *contract)
+		if err != nil {
+			return nil, err
+		}
+
+		// Once binding, participants of a contract can only be replaced by an amendment
+		err = checkDirectEdit(*contract)
+		if err != nil {
+			return nil, err
+		}
+
 		updateReq := map[string]interface{}{
 			"participants": participants,
 		}
 
+		// Participants of a contract linked to a document must be required to sign it
+		err = checkLinkedDocumentParties(stub, utils.JoinMaps(nil, *contract, updateReq))
+		if err != nil {
+			return nil, err
+		}
+
 		updatedContract, err := contract.Update(stub, updateReq)
 
 		responseJSON, nerr := json.Marshal(updatedContract)
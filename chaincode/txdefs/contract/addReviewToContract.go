@@ -57,6 +57,13 @@ var AddReviewToContract = tx.Transaction{
 			return nil, errors.WrapError(err, "Failed to get autoExecutableContract asset from ledger")
 		}
 
+		if err := checkParticipant(stub, *contract); err != nil {
+			return nil, err
+		}
+		if err := checkActive(stub, *contract); err != nil {
+			return nil, err
+		}
+
 		data, ok := (*contract)["data"].(map[string]interface{})
 		if !ok {
 			data = make(map[string]interface{})
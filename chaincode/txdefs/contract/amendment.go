@@ -0,0 +1,346 @@
+package contract
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/eventtypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/txdefs/contract/models"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/txdefs/contract/params"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+// Status of an amendment
+const (
+	AmendmentPending  = "pending"
+	AmendmentApplied  = "applied"
+	AmendmentRejected = "rejected"
+)
+
+// checkDirectEdit fails unless the owner can change the contract clauses, participants or status without
+// an amendment, which is only the case for contracts not binding yet and contracts without participants
+func checkDirectEdit(contract map[string]interface{}) errors.ICCError {
+	err := checkEditable(contract)
+	if err != nil {
+		return err
+	}
+
+	participants, _ := contract["participants"].([]interface{})
+	status := models.ContractStatus(contract)
+	if status != datatypes.ContractDraft && status != datatypes.ContractPendingSignature && len(participants) > 0 {
+		return errors.NewCCError("Contract has participants, changes must be proposed as an amendment", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// checkParty fails unless the user is the contract owner or a participant and the caller is that user
+func checkParty(stub *sw.StubWrapper, contract map[string]interface{}, user assets.Key) errors.ICCError {
+	isParty := false
+	for _, key := range utils.RefKeys(contractParties(contract)) {
+		if key == user.Key() {
+			isParty = true
+			break
+		}
+	}
+	if !isParty {
+		return errors.NewCCError("User is not a party of the contract", http.StatusForbidden)
+	}
+
+	return utils.CheckCallerIsUser(stub, user)
+}
+
+// pendingApprovals returns the contract parties that did not approve the amendment yet
+func pendingApprovals(contract, amendment map[string]interface{}) []string {
+	approved := make(map[string]bool)
+	for _, key := range utils.RefKeys(amendment["approvals"]) {
+		approved[key] = true
+	}
+
+	pending := []string{}
+	for _, key := range utils.RefKeys(contractParties(contract)) {
+		if !approved[key] {
+			pending = append(pending, key)
+		}
+	}
+	return pending
+}
+
+// amendedStatus returns the contract status proposed by the amendment, if any
+func amendedStatus(amendment map[string]interface{}) (datatypes.ContractStatusType, bool, errors.ICCError) {
+	if amendment["contractStatus"] == nil {
+		return 0, false, nil
+	}
+	status, ok := datatypes.ParseContractStatus(amendment["contractStatus"])
+	if !ok {
+		return 0, false, errors.NewCCError("Amendment has an invalid contract status", http.StatusBadRequest)
+	}
+	return status, true, nil
+}
+
+// checkAmendmentChanges fails unless the clauses removed or changed by the amendment belong to the contract,
+// the changed parameters are accepted by the clause actions and the contract can move to the status
+// proposed by the amendment
+func checkAmendmentChanges(stub *sw.StubWrapper, contract map[string]interface{}, amendment map[string]interface{}) errors.ICCError {
+	status, hasStatus, err := amendedStatus(amendment)
+	if err != nil {
+		return err
+	}
+	if hasStatus {
+		err = checkAmendedStatus(contract, status)
+		if err != nil {
+			return err
+		}
+	}
+
+	inContract := make(map[string]bool)
+	for _, key := range utils.RefKeys(contract["clauses"]) {
+		inContract[key] = true
+	}
+
+	addList, _ := amendment["addClauses"].([]interface{})
+	for i, c := range addList {
+		clause, ok := c.(map[string]interface{})
+		if !ok {
+			return errors.NewCCError(fmt.Sprintf("Added clause %d must be an object", i+1), http.StatusBadRequest)
+		}
+		if id, _ := clause["id"].(string); id == "" {
+			return errors.NewCCError(fmt.Sprintf("Added clause %d must have an id", i+1), http.StatusBadRequest)
+		}
+	}
+
+	for _, key := range utils.RefKeys(amendment["removeClauses"]) {
+		if !inContract[key] {
+			return errors.NewCCError(fmt.Sprintf("Clause %s does not belong to the contract", key), http.StatusBadRequest)
+		}
+	}
+
+	changes, _ := amendment["parameterChanges"].([]interface{})
+	for i, c := range changes {
+		change, ok := c.(map[string]interface{})
+		if !ok {
+			return errors.NewCCError(fmt.Sprintf("Parameter change %d must be an object", i+1), http.StatusBadRequest)
+		}
+		clauseKey, err := dependencyKey(change["clause"])
+		if err != nil {
+			return errors.WrapError(err, fmt.Sprintf("Invalid clause on parameter change %d", i+1))
+		}
+		if !inContract[clauseKey.Key()] {
+			return errors.NewCCError(fmt.Sprintf("Clause %s does not belong to the contract", clauseKey.Key()), http.StatusBadRequest)
+		}
+		parameters, ok := change["parameters"].(map[string]interface{})
+		if !ok {
+			return errors.NewCCError(fmt.Sprintf("Parameter change %d must have the parameters to change", i+1), http.StatusBadRequest)
+		}
+		clause, err := clauseKey.Get(stub)
+		if err != nil {
+			return errors.WrapError(err, "Failed to get clause")
+		}
+		_, err = clauseParameters(*clause, parameters)
+		if err != nil {
+			return errors.WrapError(err, fmt.Sprintf("Invalid parameter change %d", i+1))
+		}
+	}
+
+	return nil
+}
+
+// checkAmendedStatus fails unless the amendment finalizes or cancels the contract from its current status
+func checkAmendedStatus(contract map[string]interface{}, status datatypes.ContractStatusType) errors.ICCError {
+	if status != datatypes.ContractFinalized && status != datatypes.ContractCancelled {
+		return errors.NewCCError("Amendments can only finalize or cancel a contract", http.StatusBadRequest)
+	}
+	return checkTransition(models.ContractStatus(contract), status)
+}
+
+// applyAmendment removes, changes and adds the clauses of the amendment, in this order, then replaces
+// the contract participants and moves the contract to the amended status
+func applyAmendment(stub *sw.StubWrapper, contractKey assets.Key, amendment map[string]interface{}) errors.ICCError {
+	for _, key := range utils.RefKeys(amendment["removeClauses"]) {
+		_, err := removeClause(stub, map[string]interface{}{
+			"autoExecutableContract": contractKey,
+			"clause":                 assets.Key{"@assetType": "clause", "@key": key},
+		})
+		if err != nil {
+			return errors.WrapError(err, fmt.Sprintf("Failed to remove clause %s", key))
+		}
+	}
+
+	changes, _ := amendment["parameterChanges"].([]interface{})
+	for _, c := range changes {
+		change, _ := c.(map[string]interface{})
+		clauseKey, err := dependencyKey(change["clause"])
+		if err != nil {
+			return err
+		}
+		parameters, _ := change["parameters"].(map[string]interface{})
+
+		err = changeClauseParameters(stub, clauseKey, parameters)
+		if err != nil {
+			return errors.WrapError(err, fmt.Sprintf("Failed to change parameters of clause %s", clauseKey.Key()))
+		}
+	}
+
+	if addList, ok := amendment["addClauses"].([]interface{}); ok && len(addList) > 0 {
+		err := addClauses(stub, contractKey, addList)
+		if err != nil {
+			return err
+		}
+	}
+
+	if participants, ok := amendment["participants"].([]interface{}); ok {
+		contract, err := contractKey.Get(stub)
+		if err != nil {
+			return errors.WrapError(err, "Failed to get contract")
+		}
+
+		update := map[string]interface{}{
+			"participants": participants,
+		}
+		err = checkLinkedDocumentParties(stub, utils.JoinMaps(nil, *contract, update))
+		if err != nil {
+			return err
+		}
+
+		_, err = contractKey.Update(stub, update)
+		if err != nil {
+			return errors.WrapError(err, "Failed to update contract participants")
+		}
+	}
+
+	status, hasStatus, err := amendedStatus(amendment)
+	if err != nil {
+		return err
+	}
+	if hasStatus {
+		contract, err := contractKey.Get(stub)
+		if err != nil {
+			return errors.WrapError(err, "Failed to get contract")
+		}
+
+		// The contract may have changed status since the amendment was proposed
+		err = checkAmendedStatus(*contract, status)
+		if err != nil {
+			return err
+		}
+
+		_, err = contractKey.Update(stub, map[string]interface{}{
+			"status": status,
+		})
+		if err != nil {
+			return errors.WrapError(err, "Failed to update contract status")
+		}
+	}
+
+	return nil
+}
+
+// changeClauseParameters merges the parameters accepted by the clause action into its current parameters
+func changeClauseParameters(stub *sw.StubWrapper, clauseKey assets.Key, parameters map[string]interface{}) errors.ICCError {
+	clause, err := clauseKey.Get(stub)
+	if err != nil {
+		return errors.WrapError(err, "Failed to get clause")
+	}
+
+	accepted, err := clauseParameters(*clause, parameters)
+	if err != nil {
+		return err
+	}
+
+	current, _ := (*clause)["parameters"].(map[string]interface{})
+	merged := utils.JoinMaps(nil, current, accepted)
+
+	_, err = clauseKey.Update(stub, map[string]interface{}{
+		"parameters": merged,
+	})
+	if err != nil {
+		return errors.WrapError(err, "Failed to update clause")
+	}
+
+	return nil
+}
+
+// clauseParameters returns the parameters to merge into the clause, failing if its action does not
+// accept any of them, so that no proposed change is silently dropped
+func clauseParameters(clause map[string]interface{}, parameters map[string]interface{}) (map[string]interface{}, errors.ICCError) {
+	actionType, ok := datatypes.ParseActionType(clause["actionType"])
+	if !ok {
+		return nil, errors.NewCCError("Clause has an invalid action type", http.StatusBadRequest)
+	}
+	paramHandler := params.Get(actionType)
+	if paramHandler == nil {
+		return nil, errors.NewCCError("Clause action has no parameters", http.StatusBadRequest)
+	}
+
+	formatOutputNames(parameters)
+	accepted := filterFields(parameters, paramHandler.GetParameters())
+	for name := range parameters {
+		if _, ok := accepted[name]; !ok {
+			return nil, errors.NewCCError(fmt.Sprintf("Clause action has no parameter '%s'", name), http.StatusBadRequest)
+		}
+	}
+
+	return accepted, nil
+}
+
+// applyApprovedAmendment applies an amendment every party approved, marks it as applied and adds
+// it to the amendment history of the contract
+func applyApprovedAmendment(stub *sw.StubWrapper, contractKey assets.Key, amendment *assets.Asset) (map[string]interface{}, errors.ICCError) {
+	err := applyAmendment(stub, contractKey, *amendment)
+	if err != nil {
+		return nil, err
+	}
+
+	now, err := txTime(stub)
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := amendment.Update(stub, map[string]interface{}{
+		"status":    AmendmentApplied,
+		"appliedAt": now.Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, errors.WrapError(err, "Failed to update amendment")
+	}
+
+	// Applying the amendment changed the contract clauses, so the contract is read again
+	contract, err := contractKey.Get(stub)
+	if err != nil {
+		return nil, errors.WrapError(err, "Failed to get contract")
+	}
+
+	history, _ := (*contract)["amendments"].([]interface{})
+	history = append(history, map[string]interface{}{
+		"@assetType": "amendment",
+		"@key":       amendment.Key(),
+	})
+	updatedContract, err := contractKey.Update(stub, map[string]interface{}{
+		"amendments": history,
+	})
+	if err != nil {
+		return nil, errors.WrapError(err, "Failed to record amendment on contract")
+	}
+
+	name, _ := updatedContract["name"].(string)
+
+	// Fabric keeps a single event per transaction, so the amended event carries the new status
+	event := eventtypes.ContractEvent{
+		Contract: contractKey.Key(),
+		Name:     name,
+	}
+	if status, hasStatus, _ := amendedStatus(*amendment); hasStatus {
+		event.Status = status.String()
+	}
+	err = eventtypes.Emit(stub, eventtypes.ContractAmended, event)
+	if err != nil {
+		return nil, errors.WrapError(err, "Failed to emit contract amended event")
+	}
+
+	return applied, nil
+}
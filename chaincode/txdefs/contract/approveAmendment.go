@@ -0,0 +1,103 @@
+package contract
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+var ApproveAmendment = tx.Transaction{
+	Tag:         "approveAmendment",
+	Label:       "Approve Amendment",
+	Description: "Approves an amendment. It is applied to the contract once the owner and every participant approved it",
+	Method:      "POST",
+
+	Args: []tx.Argument{
+		{
+			Required: true,
+			Tag:      "amendment",
+			Label:    "Amendment",
+			DataType: "->amendment",
+		},
+		{
+			Required: true,
+			Tag:      "user",
+			Label:    "User",
+			DataType: "->user",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		amendmentKey, ok := req["amendment"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter 'amendment' must be an asset key")
+		}
+
+		user, ok := req["user"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter 'user' must be an asset key")
+		}
+
+		amendment, err := amendmentKey.Get(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get amendment")
+		}
+
+		if status, _ := (*amendment)["status"].(string); status != AmendmentPending {
+			return nil, errors.NewCCError("Amendment is already "+status, http.StatusBadRequest)
+		}
+
+		contractRef, _ := (*amendment)["autoExecutableContract"].(map[string]interface{})
+		contractKey, err := assets.NewKey(contractRef)
+		if err != nil {
+			return nil, errors.WrapError(err, "Invalid amendment contract")
+		}
+
+		contract, err := contractKey.Get(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get contract")
+		}
+
+		err = checkParty(stub, *contract, user)
+		if err != nil {
+			return nil, err
+		}
+
+		err = checkEditable(*contract)
+		if err != nil {
+			return nil, err
+		}
+
+		approvals, _ := (*amendment)["approvals"].([]interface{})
+		for _, key := range utils.RefKeys(approvals) {
+			if key == user.Key() {
+				return nil, errors.NewCCError("User already approved this amendment", http.StatusBadRequest)
+			}
+		}
+
+		res, err := amendment.Update(stub, map[string]interface{}{
+			"approvals": append(approvals, user),
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to update amendment")
+		}
+
+		if len(pendingApprovals(*contract, *amendment)) == 0 {
+			res, err = applyApprovedAmendment(stub, contractKey, amendment)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		responseJSON, nerr := json.Marshal(res)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "Failed to marshal response to JSON format")
+		}
+
+		return responseJSON, nil
+	},
+}
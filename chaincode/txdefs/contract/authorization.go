@@ -0,0 +1,59 @@
+package contract
+
+import (
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+// checkOwner fails unless the caller is the contract owner, the only one allowed to edit it
+func checkOwner(stub *sw.StubWrapper, contract map[string]interface{}) errors.ICCError {
+	return utils.CheckCallerIsUser(stub, contract["owner"])
+}
+
+// checkParticipant fails unless the caller is the contract owner or one of its participants
+func checkParticipant(stub *sw.StubWrapper, contract map[string]interface{}) errors.ICCError {
+	return utils.CheckCallerIsAnyUser(stub, contractParties(contract))
+}
+
+// contractParties returns the references of the contract owner and participants
+func contractParties(contract map[string]interface{}) []interface{} {
+	users := []interface{}{contract["owner"]}
+	if participants, ok := contract["participants"].([]interface{}); ok {
+		users = append(users, participants...)
+	}
+	return users
+}
+
+// getClauseContract returns the contract the clause belongs to
+func getClauseContract(stub *sw.StubWrapper, clauseKey assets.Key) (*assets.Asset, errors.ICCError) {
+	query := map[string]interface{}{
+		"selector": map[string]interface{}{
+			"@assetType": "autoExecutableContract",
+			"clauses": map[string]interface{}{
+				"$elemMatch": map[string]interface{}{
+					"@key": clauseKey.Key(),
+				},
+			},
+		},
+	}
+
+	response, err := assets.Search(stub, query, "", false)
+	if err != nil {
+		return nil, errors.WrapErrorWithStatus(err, "error searching for the clause contract", http.StatusInternalServerError)
+	}
+
+	if len(response.Result) == 0 {
+		return nil, errors.NewCCError("Clause does not belong to any contract", http.StatusNotFound)
+	}
+
+	contractKey := assets.Key{
+		"@assetType": "autoExecutableContract",
+		"@key":       response.Result[0]["@key"],
+	}
+
+	return contractKey.Get(stub)
+}
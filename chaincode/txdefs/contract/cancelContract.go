@@ -2,6 +2,7 @@ package contract
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/hyperledger-labs/cc-tools/assets"
@@ -61,6 +62,25 @@ var CancelContract = tx.Transaction{
 			return nil, errors.NewCCError("please provide one condition to cancel the contract", 400)
 		}
 
+		// Any participant can request the cancellation, only the owner can force it
+		clauseContract, err := getClauseContract(stub, clauseKey)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get clause contract")
+		}
+		if req["forceCancellation"] != nil {
+			err = checkOwner(stub, *clauseContract)
+		} else {
+			err = checkParticipant(stub, *clauseContract)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		err = checkEditable(*clauseContract)
+		if err != nil {
+			return nil, err
+		}
+
 		var parameters params.FinalizeContractParams
 		if clause.Parameters != nil {
 			bytes, jerr := json.Marshal(clause.Parameters)
@@ -129,9 +149,24 @@ var CancelContract = tx.Transaction{
 				return nil, errors.WrapError(err, "Failed to get contract")
 			}
 
-			err = ExecuteClause(stub, contractAsset, updateClauseAsset)
+			// The cancellation clause may depend on other clauses, which are executed first
+			updateClauseAsset = contractAsset.GetClause(updateClauseAsset.Key)
+			if updateClauseAsset == nil {
+				return nil, errors.NewCCError("clause is not associated with any contract", 400)
+			}
+			results, skippedClauses, err := ExecuteClauses(stub, contractAsset, []*models.Clause{updateClauseAsset})
+			if err != nil {
+				return nil, err
+			}
+			for _, skipped := range skippedClauses {
+				if skipped.Clause == updateClauseAsset.Key {
+					return nil, errors.NewCCError(fmt.Sprintf("Failed to execute clause: %s", skipped.Error), http.StatusBadRequest)
+				}
+			}
+
+			err = emitExecutionEvent(stub, contractAsset, results, skippedClauses)
 			if err != nil {
-				return nil, errors.WrapError(err, "Failed to execute clause")
+				return nil, err
 			}
 		} else {
 			return nil, errors.NewCCError("clause is not associated with any contract", 400)
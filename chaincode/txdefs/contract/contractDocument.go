@@ -0,0 +1,152 @@
+package contract
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+// contractDocument returns the document linked to the contract, or nil for contracts without one
+func contractDocument(stub *sw.StubWrapper, contract map[string]interface{}) (*assets.Key, *assets.Asset, errors.ICCError) {
+	documentRef, ok := contract["document"]
+	if !ok || documentRef == nil {
+		return nil, nil, nil
+	}
+
+	var documentKey assets.Key
+	switch ref := documentRef.(type) {
+	case assets.Key:
+		documentKey = ref
+	case map[string]interface{}:
+		documentKey = assets.Key{"@assetType": "document", "@key": ref["@key"]}
+	default:
+		return nil, nil, errors.NewCCError("Invalid contract document", http.StatusBadRequest)
+	}
+
+	document, err := documentKey.Get(stub)
+	if err != nil {
+		return nil, nil, errors.WrapError(err, "Failed to get contract document")
+	}
+
+	return &documentKey, document, nil
+}
+
+// checkDocumentParties fails unless the owner and every participant of the contract are required
+// signers of its document, so that signing the document binds every party of the contract
+func checkDocumentParties(contract, document map[string]interface{}) errors.ICCError {
+	required := make(map[string]bool)
+	for _, key := range utils.RefKeys(document["requiredSignatures"]) {
+		required[key] = true
+	}
+
+	for _, key := range utils.RefKeys(contractParties(contract)) {
+		if !required[key] {
+			return errors.NewCCError(fmt.Sprintf("Contract party %s is not a required signer of the contract document", key), http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// checkLinkedDocumentParties checks the parties of a contract against its document, if it has one
+func checkLinkedDocumentParties(stub *sw.StubWrapper, contract map[string]interface{}) errors.ICCError {
+	_, document, err := contractDocument(stub, contract)
+	if err != nil || document == nil {
+		return err
+	}
+	return checkDocumentParties(contract, *document)
+}
+
+// linkedStatus returns the status a contract leaves draft with. Contracts linked to a document are
+// pending until the document is finalized and are then signed on the date of its last signature,
+// which is returned along with the status. Only the document owner can link it to a contract.
+func linkedStatus(stub *sw.StubWrapper, contract map[string]interface{}) (datatypes.ContractStatusType, time.Time, errors.ICCError) {
+	documentKey, document, err := contractDocument(stub, contract)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	if document == nil {
+		return datatypes.ContractActive, time.Time{}, nil
+	}
+
+	err = utils.CheckCallerIsUser(stub, (*document)["owner"])
+	if err != nil {
+		return 0, time.Time{}, errors.WrapErrorWithStatus(err, "Only the document owner can link it to a contract", http.StatusForbidden)
+	}
+
+	err = checkDocumentParties(contract, *document)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	if (*document)["supersededBy"] != nil {
+		return 0, time.Time{}, errors.NewCCError("Contract document was amended, link its latest version", http.StatusBadRequest)
+	}
+
+	switch status, _ := datatypes.ParseStatus((*document)["status"]); status {
+	case 1, 2:
+		return 0, time.Time{}, errors.NewCCError("Contract document is cancelled or expired", http.StatusBadRequest)
+	case 3:
+		signatureDate, err := lastSignatureDate(stub, documentKey.Key())
+		if err != nil {
+			return 0, time.Time{}, err
+		}
+		return datatypes.ContractActive, signatureDate, nil
+	default:
+		return datatypes.ContractPendingSignature, time.Time{}, nil
+	}
+}
+
+// lastSignatureDate returns when the last signature of the document was made
+func lastSignatureDate(stub *sw.StubWrapper, documentKey string) (time.Time, errors.ICCError) {
+	query := map[string]interface{}{
+		"selector": map[string]interface{}{
+			"@assetType":    "signature",
+			"document.@key": documentKey,
+		},
+	}
+
+	response, err := assets.Search(stub, query, "", true)
+	if err != nil {
+		return time.Time{}, errors.WrapErrorWithStatus(err, "error searching for document signatures", http.StatusInternalServerError)
+	}
+
+	var last time.Time
+	for _, signature := range response.Result {
+		signedAt, _ := signature["signedAt"].(string)
+		t, nerr := time.Parse(time.RFC3339, signedAt)
+		if nerr == nil && t.After(last) {
+			last = t
+		}
+	}
+
+	if last.IsZero() {
+		return time.Time{}, errors.NewCCError("Contract document has no recorded signatures", http.StatusBadRequest)
+	}
+
+	return last, nil
+}
+
+// signedContractUpdate returns the fields that activate a contract signed on the given date
+func signedContractUpdate(contract map[string]interface{}, status datatypes.ContractStatusType, signatureDate time.Time) map[string]interface{} {
+	update := map[string]interface{}{
+		"status": status,
+	}
+	if !signatureDate.IsZero() {
+		dates, ok := contract["dates"].(map[string]interface{})
+		if !ok {
+			dates = make(map[string]interface{})
+		}
+		dates["signature"] = signatureDate.Format(time.RFC3339)
+
+		update["signatureDate"] = signatureDate.Format(time.RFC3339)
+		update["dates"] = dates
+	}
+	return update
+}
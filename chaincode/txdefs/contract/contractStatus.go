@@ -0,0 +1,73 @@
+package contract
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/txdefs/contract/models"
+)
+
+// contractTransitions lists the statuses each contract status can move to.
+// Finalized, cancelled and expired contracts cannot change status.
+var contractTransitions = map[datatypes.ContractStatusType][]datatypes.ContractStatusType{
+	datatypes.ContractDraft:            {datatypes.ContractActive, datatypes.ContractPendingSignature, datatypes.ContractCancelled},
+	datatypes.ContractPendingSignature: {datatypes.ContractActive, datatypes.ContractCancelled},
+	datatypes.ContractActive:           {datatypes.ContractSuspended, datatypes.ContractFinalized, datatypes.ContractCancelled, datatypes.ContractExpired},
+	datatypes.ContractSuspended:        {datatypes.ContractActive, datatypes.ContractCancelled, datatypes.ContractExpired},
+}
+
+// checkTransition fails unless a contract can move from one status to the other
+func checkTransition(from, to datatypes.ContractStatusType) errors.ICCError {
+	for _, allowed := range contractTransitions[from] {
+		if allowed == to {
+			return nil
+		}
+	}
+	return errors.NewCCError(fmt.Sprintf("Contract cannot move from %s to %s", from, to), http.StatusBadRequest)
+}
+
+// contractEnded tells whether the end date of the contract is before the transaction timestamp
+func contractEnded(stub *sw.StubWrapper, contract map[string]interface{}) (bool, errors.ICCError) {
+	endDate, ok := models.ParseTime(contract["endDate"])
+	if !ok {
+		return false, nil
+	}
+
+	now, err := txTime(stub)
+	if err != nil {
+		return false, err
+	}
+
+	return endDate.Before(now), nil
+}
+
+// checkEditable fails if the contract clauses and participants can no longer be changed
+func checkEditable(contract map[string]interface{}) errors.ICCError {
+	status := models.ContractStatus(contract)
+	if status.IsTerminal() {
+		return errors.NewCCError(fmt.Sprintf("Contract is %s and cannot be changed", status), http.StatusBadRequest)
+	}
+	return nil
+}
+
+// checkActive fails unless the contract is active and its end date has not passed,
+// which is required to add inputs to its clauses and to execute them
+func checkActive(stub *sw.StubWrapper, contract map[string]interface{}) errors.ICCError {
+	status := models.ContractStatus(contract)
+	if status != datatypes.ContractActive {
+		return errors.NewCCError(fmt.Sprintf("Contract is %s, only active contracts accept inputs and executions", status), http.StatusBadRequest)
+	}
+
+	ended, err := contractEnded(stub, contract)
+	if err != nil {
+		return err
+	}
+	if ended {
+		return errors.NewCCError("Contract end date has passed", http.StatusBadRequest)
+	}
+
+	return nil
+}
@@ -2,11 +2,15 @@ package contract
 
 import (
 	"encoding/json"
+	"net/http"
 
 	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/errors"
 	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
 	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/eventtypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
 )
 
 var CreateAutoExecutableContract = tx.Transaction{
@@ -23,10 +27,10 @@ var CreateAutoExecutableContract = tx.Transaction{
 			DataType: "string",
 		},
 		{
-			Required: true,
-			Tag:      "signatureDate",
-			Label:    "Signature Date",
-			DataType: "datetime",
+			Tag:         "signatureDate",
+			Label:       "Signature Date",
+			DataType:    "datetime",
+			Description: "Required unless the contract is linked to a document, which sets it when fully signed",
 		},
 		{
 			Tag:      "clauses",
@@ -49,6 +53,29 @@ var CreateAutoExecutableContract = tx.Transaction{
 			Label:    "Participants",
 			DataType: "[]->user",
 		},
+		{
+			Tag:      "template",
+			Label:    "Template",
+			DataType: "->template",
+		},
+		{
+			Tag:         "document",
+			Label:       "Document",
+			DataType:    "->document",
+			Description: "Document with the signed text of the contract, owned by the contract owner and requiring every party to sign. The contract is pending until the document is finalized",
+		},
+		{
+			Tag:         "endDate",
+			Label:       "End Date",
+			DataType:    "datetime",
+			Description: "The contract expires after this date",
+		},
+		{
+			Tag:         "draft",
+			Label:       "Draft",
+			DataType:    "boolean",
+			Description: "Creates the contract as a draft, which only accepts inputs and executions once activated",
+		},
 	},
 	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
 
@@ -63,23 +90,59 @@ var CreateAutoExecutableContract = tx.Transaction{
 			return nil, errors.WrapError(nil, "Parameter 'owner' must be an asset key")
 		}
 
+		// Contracts can only be created by their owner
+		err := utils.CheckCallerIsUser(stub, owner)
+		if err != nil {
+			return nil, err
+		}
+
 		contract := map[string]interface{}{
-			"@assetType":    "autoExecutableContract",
-			"name":          name,
-			"signatureDate": signatureDate,
-			"owner":         owner,
-			"dates":         map[string]interface{}{"signature": signatureDate},
+			"@assetType": "autoExecutableContract",
+			"name":       name,
+			"owner":      owner,
+			"dates":      map[string]interface{}{},
 		}
 
-		if clauses, ok := req["clauses"].([]interface{}); ok {
-			contract["clauses"] = clauses
+		if signatureDate != nil {
+			contract["signatureDate"] = signatureDate
+			contract["dates"] = map[string]interface{}{"signature": signatureDate}
+		}
+		if document, ok := req["document"].(assets.Key); ok {
+			contract["document"] = document
 		}
+		if contract["signatureDate"] == nil && contract["document"] == nil {
+			return nil, errors.NewCCError("Parameter 'signatureDate' is required for contracts without a document", http.StatusBadRequest)
+		}
+
 		if participants, ok := req["participants"].([]interface{}); ok {
 			contract["participants"] = participants
 		}
+
+		// Contracts linked to a document are only binding once the document is signed
+		status, signedAt, err := linkedStatus(stub, contract)
+		if err != nil {
+			return nil, err
+		}
+		if draft, _ := req["draft"].(bool); draft {
+			contract["status"] = datatypes.ContractDraft
+		} else {
+			for k, v := range signedContractUpdate(contract, status, signedAt) {
+				contract[k] = v
+			}
+		}
+		if endDate, ok := req["endDate"]; ok && endDate != nil {
+			contract["endDate"] = endDate
+		}
+
+		if clauses, ok := req["clauses"].([]interface{}); ok {
+			contract["clauses"] = clauses
+		}
 		if data, ok := req["data"].(map[string]interface{}); ok {
 			contract["data"] = data
 		}
+		if template, ok := req["template"].(assets.Key); ok {
+			contract["template"] = template
+		}
 
 		newContract, err := assets.NewAsset(contract)
 		if err != nil {
@@ -91,6 +154,14 @@ var CreateAutoExecutableContract = tx.Transaction{
 			return nil, errors.WrapError(err, "Failed to write contract asset to the ledger")
 		}
 
+		err = eventtypes.Emit(stub, eventtypes.ContractCreated, eventtypes.ContractEvent{
+			Contract: newContract.Key(),
+			Name:     name,
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to emit contract created event")
+		}
+
 		resBytes, e := json.Marshal(res)
 		if e != nil {
 			return nil, errors.WrapError(e, "Failed to marshal response")
@@ -0,0 +1,360 @@
+package contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+var CreateContractFromTemplate = tx.Transaction{
+	Tag:         "createContractFromTemplate",
+	Label:       "Create Contract From Template",
+	Description: "Creates an auto executable contract with a clause for each clause of a template",
+	Method:      "POST",
+
+	Args: []tx.Argument{
+		{
+			Required: true,
+			Tag:      "template",
+			Label:    "Template",
+			DataType: "->template",
+		},
+		{
+			Required: true,
+			Tag:      "name",
+			Label:    "Name",
+			DataType: "string",
+		},
+		{
+			Tag:         "signatureDate",
+			Label:       "Signature Date",
+			DataType:    "datetime",
+			Description: "Required unless the contract is linked to a document, which sets it when fully signed",
+		},
+		{
+			Required: true,
+			Tag:      "owner",
+			Label:    "Owner",
+			DataType: "->user",
+		},
+		{
+			Tag:      "participants",
+			Label:    "Participants",
+			DataType: "[]->user",
+		},
+		{
+			Tag:      "data",
+			Label:    "Data",
+			DataType: "@object",
+		},
+		{
+			Tag:         "clauseOverrides",
+			Label:       "Clause Overrides",
+			DataType:    "@object",
+			Description: "Parameters and inputs that override the template defaults, indexed by template clause id. e.g. {\"clauseId\": {\"parameters\": {}, \"input\": {}}}",
+		},
+		{
+			Tag:         "skipClauses",
+			Label:       "Skip Clauses",
+			DataType:    "[]->templateClause",
+			Description: "Optional template clauses that should not be added to the contract",
+		},
+		{
+			Tag:         "clauseIdPrefix",
+			Label:       "Clause Id Prefix",
+			DataType:    "string",
+			Description: "Prefix used on the id of the new clauses. Defaults to the contract name",
+		},
+		{
+			Tag:         "document",
+			Label:       "Document",
+			DataType:    "->document",
+			Description: "Document with the signed text of the contract, owned by the contract owner and requiring every party to sign. The contract is pending until the document is finalized",
+		},
+		{
+			Tag:         "endDate",
+			Label:       "End Date",
+			DataType:    "datetime",
+			Description: "The contract expires after this date",
+		},
+		{
+			Tag:         "draft",
+			Label:       "Draft",
+			DataType:    "boolean",
+			Description: "Creates the contract as a draft, which only accepts inputs and executions once activated",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		templateKey, ok := req["template"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter 'template' must be an asset key")
+		}
+
+		name, ok := req["name"].(string)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter 'name' must be a string")
+		}
+
+		templateAsset, err := templateKey.Get(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get template asset from ledger")
+		}
+
+		templateClauses, err := getTemplateClauses(stub, templateAsset)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get template clauses")
+		}
+
+		overrides, _ := req["clauseOverrides"].(map[string]interface{})
+
+		skipped := make(map[string]bool)
+		if skipClauses, ok := req["skipClauses"].([]interface{}); ok {
+			for _, s := range skipClauses {
+				skipKey, ok := s.(assets.Key)
+				if !ok {
+					return nil, errors.NewCCError("Invalid skipClauses entry", http.StatusBadRequest)
+				}
+				skipped[skipKey.Key()] = true
+			}
+		}
+
+		idPrefix := name
+		if prefix, ok := req["clauseIdPrefix"].(string); ok && prefix != "" {
+			idPrefix = prefix
+		}
+
+		// Order template clauses so dependencies are created before the clauses that reference them
+		order := make([]string, 0, len(templateClauses))
+		dependencies := make(map[string][]string)
+		for _, tc := range templateClauses {
+			order = append(order, tc.Key())
+			dependencies[tc.Key()] = utils.RefKeys((*tc)["dependencies"])
+		}
+
+		sorted, cyclic := utils.SortDependencies(order, dependencies)
+		if len(cyclic) > 0 {
+			return nil, errors.NewCCError(fmt.Sprintf("Template clauses have cyclic dependencies: %v", cyclic), http.StatusBadRequest)
+		}
+
+		templateClauseByKey := make(map[string]*assets.Asset)
+		for _, tc := range templateClauses {
+			templateClauseByKey[tc.Key()] = tc
+		}
+
+		for skipKey := range skipped {
+			tc, exists := templateClauseByKey[skipKey]
+			if !exists {
+				return nil, errors.NewCCError(fmt.Sprintf("Template clause %s does not belong to the template", skipKey), http.StatusBadRequest)
+			}
+			if optional, _ := (*tc)["optional"].(bool); !optional {
+				return nil, errors.NewCCError(fmt.Sprintf("Template clause %s is not optional", (*tc)["id"]), http.StatusBadRequest)
+			}
+		}
+
+		contractArgs := map[string]interface{}{
+			"name":     name,
+			"owner":    req["owner"],
+			"template": templateKey,
+		}
+		if signatureDate, ok := req["signatureDate"]; ok && signatureDate != nil {
+			contractArgs["signatureDate"] = signatureDate
+		}
+		if document, ok := req["document"].(assets.Key); ok {
+			contractArgs["document"] = document
+		}
+		if participants, ok := req["participants"].([]interface{}); ok {
+			contractArgs["participants"] = participants
+		}
+		if data, ok := req["data"].(map[string]interface{}); ok {
+			contractArgs["data"] = data
+		}
+		if endDate, ok := req["endDate"]; ok && endDate != nil {
+			contractArgs["endDate"] = endDate
+		}
+		// The contract is a draft while the template clauses are added, so they need no amendment
+		draft, _ := req["draft"].(bool)
+		contractArgs["draft"] = true
+
+		contractBytes, err := CreateAutoExecutableContract.Routine(stub, contractArgs)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to create contract")
+		}
+
+		var contract map[string]interface{}
+		nerr := json.Unmarshal(contractBytes, &contract)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "Failed to unmarshal created contract")
+		}
+
+		contractKey := assets.Key{
+			"@assetType": "autoExecutableContract",
+			"@key":       contract["@key"],
+		}
+
+		clauseKeys := make(map[string]assets.Key)
+		for _, tcKey := range sorted {
+			if skipped[tcKey] {
+				continue
+			}
+			tc := *templateClauseByKey[tcKey]
+
+			tcId, _ := tc["id"].(string)
+
+			var clauseDependencies []interface{}
+			for _, depKey := range dependencies[tcKey] {
+				depTemplateClause, exists := templateClauseByKey[depKey]
+				if !exists {
+					return nil, errors.NewCCError(fmt.Sprintf("Template clause %s depends on a clause from another template", tcId), http.StatusBadRequest)
+				}
+				if skipped[depKey] {
+					return nil, errors.NewCCError(fmt.Sprintf("Template clause %s depends on skipped clause %s", tcId, (*depTemplateClause)["id"]), http.StatusBadRequest)
+				}
+				clauseDependencies = append(clauseDependencies, clauseKeys[depKey])
+			}
+
+			actionType, ok := tc["actionType"].(datatypes.ActionType)
+			if !ok {
+				return nil, errors.NewCCError(fmt.Sprintf("Invalid action type on template clause %s", tcId), http.StatusBadRequest)
+			}
+
+			defaultParameters, _ := tc["defaultParameters"].(map[string]interface{})
+			defaultInputs, _ := tc["defaultInputs"].(map[string]interface{})
+
+			var overrideParameters, overrideInputs map[string]interface{}
+			if override, ok := overrides[tcId].(map[string]interface{}); ok {
+				overrideParameters, _ = override["parameters"].(map[string]interface{})
+				overrideInputs, _ = override["input"].(map[string]interface{})
+			}
+
+			clauseId := fmt.Sprintf("%s_%s", idPrefix, tcId)
+			clauseArgs := map[string]interface{}{
+				"autoExecutableContract": contractKey,
+				"id":                     clauseId,
+				"actionType":             actionType,
+				"parameters":             utils.JoinMaps(nil, defaultParameters, overrideParameters),
+				"input":                  utils.JoinMaps(nil, defaultInputs, overrideInputs),
+			}
+			if description, ok := tc["description"].(string); ok {
+				clauseArgs["description"] = description
+			}
+			if category, ok := tc["category"].(string); ok {
+				clauseArgs["category"] = category
+			}
+			if len(clauseDependencies) > 0 {
+				clauseArgs["dependencies"] = clauseDependencies
+			}
+
+			_, err = AddClause.Routine(stub, clauseArgs)
+			if err != nil {
+				return nil, errors.WrapError(err, fmt.Sprintf("Failed to add clause from template clause %s", tcId))
+			}
+
+			clauseKeys[tcKey], err = assets.NewKey(map[string]interface{}{
+				"@assetType": "clause",
+				"id":         clauseId,
+			})
+			if err != nil {
+				return nil, errors.WrapError(err, "Failed to generate clause key")
+			}
+		}
+
+		contractAsset, err := contractKey.Get(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get created contract from ledger")
+		}
+
+		// The document link is checked for drafts too, even though they are only activated later
+		status, signedAt, err := linkedStatus(stub, *contractAsset)
+		if err != nil {
+			return nil, err
+		}
+		if !draft {
+			_, err = contractAsset.Update(stub, signedContractUpdate(*contractAsset, status, signedAt))
+			if err != nil {
+				return nil, errors.WrapError(err, "Failed to activate contract")
+			}
+		}
+
+		responseJSON, nerr := json.Marshal(contractAsset)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "Failed to marshal response to JSON format")
+		}
+
+		return responseJSON, nil
+	},
+}
+
+// getTemplateClauses returns the clauses listed on the template and the ones referencing it, sorted by number
+func getTemplateClauses(stub *sw.StubWrapper, template *assets.Asset) ([]*assets.Asset, errors.ICCError) {
+	var clauses []*assets.Asset
+	found := make(map[string]bool)
+
+	add := func(keyMap map[string]interface{}) errors.ICCError {
+		key, err := assets.NewKey(keyMap)
+		if err != nil {
+			return errors.WrapError(err, "Failed to make template clause key")
+		}
+		if found[key.Key()] {
+			return nil
+		}
+
+		clause, err := key.Get(stub)
+		if err != nil {
+			return errors.WrapError(err, "Failed to get template clause")
+		}
+
+		found[key.Key()] = true
+		clauses = append(clauses, clause)
+		return nil
+	}
+
+	listed, _ := (*template)["clauses"].([]interface{})
+	for _, c := range listed {
+		keyMap, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		err := add(keyMap)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	query := map[string]interface{}{
+		"selector": map[string]interface{}{
+			"@assetType":    "templateClause",
+			"template.@key": template.Key(),
+		},
+	}
+
+	response, err := assets.Search(stub, query, "", false)
+	if err != nil {
+		return nil, errors.WrapErrorWithStatus(err, "error searching for template clauses", http.StatusInternalServerError)
+	}
+
+	for _, c := range response.Result {
+		err = add(map[string]interface{}{
+			"@assetType": "templateClause",
+			"@key":       c["@key"],
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(clauses, func(i, j int) bool {
+		numberI, _ := (*clauses[i])["number"].(float64)
+		numberJ, _ := (*clauses[j])["number"].(float64)
+		return numberI < numberJ
+	})
+
+	return clauses, nil
+}
@@ -2,6 +2,8 @@ package contract
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/http"
 
 	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/errors"
@@ -123,6 +125,11 @@ var CreateTemplateClause = tx.Transaction{
 		}
 
 		if dependencies, ok := req["dependencies"].([]interface{}); ok {
+			// A new template clause cannot be depended on yet, so only the template has to be checked
+			_, err := checkTemplateDependencies(stub, template.Key(), dependencies)
+			if err != nil {
+				return nil, err
+			}
 			templateClause["dependencies"] = dependencies
 		}
 
@@ -155,3 +162,27 @@ var CreateTemplateClause = tx.Transaction{
 		return resBytes, nil
 	},
 }
+
+// checkTemplateDependencies fails if any dependency is not a clause of the template, returning the dependency keys otherwise
+func checkTemplateDependencies(stub *sw.StubWrapper, templateKey string, dependencies []interface{}) ([]string, errors.ICCError) {
+	var keys []string
+	for _, d := range dependencies {
+		depKey, ok := d.(assets.Key)
+		if !ok {
+			return nil, errors.NewCCError("Dependencies must be template clause keys", http.StatusBadRequest)
+		}
+
+		dep, err := depKey.Get(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get template clause dependency")
+		}
+
+		depTemplate, _ := (*dep)["template"].(map[string]interface{})
+		if depTemplate["@key"] != templateKey {
+			return nil, errors.NewCCError(fmt.Sprintf("Dependency %s is not a clause of the same template", (*dep)["id"]), http.StatusBadRequest)
+		}
+
+		keys = append(keys, depKey.Key())
+	}
+	return keys, nil
+}
@@ -2,11 +2,13 @@ package contract
 
 import (
 	"encoding/json"
+	"net/http"
 
 	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/errors"
 	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
 	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
 )
 
 var EditTemplateClause = tx.Transaction{
@@ -95,6 +97,10 @@ var EditTemplateClause = tx.Transaction{
 		}
 
 		if dependencies, ok := req["dependencies"].([]interface{}); ok {
+			err := checkTemplateClauseGraph(stub, templateClause, dependencies)
+			if err != nil {
+				return nil, err
+			}
 			updateReq["dependencies"] = dependencies
 		}
 
@@ -129,3 +135,52 @@ var EditTemplateClause = tx.Transaction{
 		return responseJSON, nil
 	},
 }
+
+// checkTemplateClauseGraph fails if the new dependencies of a template clause are not clauses of
+// its template or would make the template dependencies cyclic
+func checkTemplateClauseGraph(stub *sw.StubWrapper, templateClause *assets.Asset, dependencies []interface{}) errors.ICCError {
+	templateRef, _ := (*templateClause)["template"].(map[string]interface{})
+	templateKey, err := assets.NewKey(templateRef)
+	if err != nil {
+		return errors.WrapError(err, "Failed to make template key")
+	}
+
+	depKeys, err := checkTemplateDependencies(stub, templateKey.Key(), dependencies)
+	if err != nil {
+		return err
+	}
+
+	for _, depKey := range depKeys {
+		if depKey == templateClause.Key() {
+			return errors.NewCCError("A template clause cannot depend on itself", http.StatusBadRequest)
+		}
+	}
+
+	template, err := templateKey.Get(stub)
+	if err != nil {
+		return errors.WrapError(err, "Failed to get template asset from ledger")
+	}
+
+	templateClauses, err := getTemplateClauses(stub, template)
+	if err != nil {
+		return errors.WrapError(err, "Failed to get template clauses")
+	}
+
+	var order []string
+	graph := make(map[string][]string)
+	for _, tc := range templateClauses {
+		order = append(order, tc.Key())
+		graph[tc.Key()] = utils.RefKeys((*tc)["dependencies"])
+	}
+	if _, listed := graph[templateClause.Key()]; !listed {
+		order = append(order, templateClause.Key())
+	}
+	graph[templateClause.Key()] = depKeys
+
+	_, cyclic := utils.SortDependencies(order, graph)
+	if len(cyclic) > 0 {
+		return errors.NewCCError("Dependencies would create a cycle between the template clauses", http.StatusBadRequest)
+	}
+
+	return nil
+}
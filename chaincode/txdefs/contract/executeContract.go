@@ -4,11 +4,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/errors"
 	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
 	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/eventtypes"
 	"github.com/hyperledger-labs/clausia-cc/chaincode/txdefs/contract/models"
 	"github.com/hyperledger-labs/clausia-cc/chaincode/txdefs/contract/params"
 	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
@@ -37,12 +40,33 @@ var ExecuteAutoExecutableContract = tx.Transaction{
 			return nil, errors.WrapError(err, "Failed to get auto executable contract")
 		}
 
-		for _, clause := range contract.Clauses {
-			err = ExecuteClause(stub, contract, clause)
-			if err != nil {
-				fmt.Printf("Error executing clause %s: %v\n", clause.Key, err)
-				continue
-			}
+		err = checkParticipant(stub, *contract.Asset)
+		if err != nil {
+			return nil, err
+		}
+
+		// Contracts past their end date expire instead of being executed
+		ended, err := contractEnded(stub, *contract.Asset)
+		if err != nil {
+			return nil, err
+		}
+		if ended && (contract.Status == datatypes.ContractActive || contract.Status == datatypes.ContractSuspended) {
+			return expireContract(stub, contract)
+		}
+
+		err = checkActive(stub, *contract.Asset)
+		if err != nil {
+			return nil, err
+		}
+
+		results, skippedClauses, err := ExecuteClauses(stub, contract, contract.Clauses)
+		if err != nil {
+			return nil, err
+		}
+
+		err = emitExecutionEvent(stub, contract, results, skippedClauses)
+		if err != nil {
+			return nil, err
 		}
 
 		updatedContract, err := contract.Asset.Update(stub, map[string]interface{}{
@@ -61,41 +85,405 @@ var ExecuteAutoExecutableContract = tx.Transaction{
 	},
 }
 
-func ExecuteClause(stub *sw.StubWrapper, contract *models.AutoExecutableContract, clause *models.Clause) errors.ICCError {
-	if clause.Finalized || !clause.Executable {
+// SkippedClause is a clause that could not be executed and the reason why
+type SkippedClause struct {
+	Clause string `json:"clause"`
+	Id     string `json:"id"`
+	Error  string `json:"error"`
+}
+
+// ClauseResult is the outcome of executing a clause
+type ClauseResult struct {
+	Clause    string                   `json:"clause"`
+	Id        string                   `json:"id"`
+	Success   bool                     `json:"success"`
+	Feedback  string                   `json:"feedback"`
+	Finalized bool                     `json:"finalized"`
+	Meta      map[string]interface{}   `json:"meta,omitempty"`
+	Assets    []map[string]interface{} `json:"assets,omitempty"`
+}
+
+// executionOptions changes how clauses are executed. The zero value executes the clauses
+// as of the transaction timestamp and writes the results to the ledger.
+type executionOptions struct {
+	dryRun bool                              // nothing is written to the ledger
+	asOf   string                            // execution date used instead of the transaction timestamp
+	inputs map[string]map[string]interface{} // inputs that override the stored ones, indexed by clause key
+}
+
+// ExecuteClauses executes the target clauses and their dependencies, each clause at most once and
+// always after its dependencies. Clauses in a dependency cycle, clauses whose action failed and
+// clauses depending on any of them are skipped. Failing to write the results of a clause fails
+// the whole execution, since the writes already made for it cannot be undone.
+func ExecuteClauses(stub *sw.StubWrapper, contract *models.AutoExecutableContract, targets []*models.Clause) ([]ClauseResult, []SkippedClause, errors.ICCError) {
+	return executeClauses(stub, contract, targets, executionOptions{})
+}
+
+func executeClauses(stub *sw.StubWrapper, contract *models.AutoExecutableContract, targets []*models.Clause, opts executionOptions) ([]ClauseResult, []SkippedClause, errors.ICCError) {
+	results := []ClauseResult{}
+	skippedClauses := []SkippedClause{}
+	skipped := make(map[string]bool)
+	skip := func(clause *models.Clause, reason string) {
+		skipped[clause.Key] = true
+		skippedClauses = append(skippedClauses, SkippedClause{Clause: clause.Key, Id: clause.Id, Error: reason})
+	}
+
+	order, cyclic := executionOrder(contract, targets)
+	for _, clause := range cyclic {
+		skip(clause, "Clause is part of a dependency cycle or depends on one")
+	}
+
+	for _, clause := range order {
+		// A finish contract clause may close the contract before the remaining clauses run
+		if contract.Status.IsTerminal() {
+			skip(clause, fmt.Sprintf("Contract is %s", contract.Status))
+			continue
+		}
+
+		failedDependency := ""
+		for _, dep := range clause.Dependencies {
+			if skipped[dep.Key()] {
+				failedDependency = dep.Key()
+				break
+			}
+		}
+		if failedDependency != "" {
+			skip(clause, fmt.Sprintf("Dependency %s was skipped", failedDependency))
+			continue
+		}
+
+		run, err := runClause(stub, contract, clause, opts)
+		if err != nil {
+			skip(clause, err.Error())
+			continue
+		}
+		if run == nil {
+			continue
+		}
+
+		result, err := applyClauseRun(stub, contract, clause, run, opts)
+		if err != nil {
+			// A dry run writes nothing, so the clause can still be skipped
+			if opts.dryRun {
+				skip(clause, err.Error())
+				continue
+			}
+			return nil, nil, errors.WrapError(err, fmt.Sprintf("Failed to record execution of clause %s", clause.Id))
+		}
+		results = append(results, *result)
+	}
+
+	return results, skippedClauses, nil
+}
+
+// emitExecutionEvent emits the most significant event of the executed clauses with all their results
+// and the clauses that were skipped
+func emitExecutionEvent(stub *sw.StubWrapper, contract *models.AutoExecutableContract, results []ClauseResult, skippedClauses []SkippedClause) errors.ICCError {
+	if len(results) == 0 && len(skippedClauses) == 0 {
 		return nil
 	}
 
+	event := eventtypes.ClauseExecuted
+	data := eventtypes.ContractEvent{
+		Contract: contract.Key,
+		Name:     contract.Name,
+	}
+	for _, r := range results {
+		data.Clauses = append(data.Clauses, eventtypes.ClauseEvent{
+			Clause:    r.Clause,
+			Id:        r.Id,
+			Success:   r.Success,
+			Feedback:  r.Feedback,
+			Finalized: r.Finalized,
+		})
+
+		switch {
+		case r.Meta["contractStatus"] == params.ContractCancelled:
+			event = eventtypes.ContractCancelled
+		case r.Meta["contractStatus"] == params.ContractFinalized && event.Tag != eventtypes.ContractCancelled.Tag:
+			event = eventtypes.ContractFinalized
+		case r.Finalized && event.Tag == eventtypes.ClauseExecuted.Tag:
+			event = eventtypes.ClauseFinalized
+		}
+	}
+
+	for _, s := range skippedClauses {
+		data.Clauses = append(data.Clauses, eventtypes.ClauseEvent{
+			Clause:   s.Clause,
+			Id:       s.Id,
+			Feedback: s.Error,
+			Skipped:  true,
+		})
+	}
+
+	return eventtypes.Emit(stub, event, data)
+}
+
+// executionOrder returns the targets and the clauses they depend on, sorted so that each clause comes after its dependencies
+func executionOrder(contract *models.AutoExecutableContract, targets []*models.Clause) (order []*models.Clause, cyclic []*models.Clause) {
+	var keys []string
+	dependencies := make(map[string][]string)
+	visited := make(map[string]bool)
+
+	var visit func(clause *models.Clause)
+	visit = func(clause *models.Clause) {
+		if visited[clause.Key] {
+			return
+		}
+		visited[clause.Key] = true
+		keys = append(keys, clause.Key)
+
+		for _, dep := range clause.Dependencies {
+			dependencies[clause.Key] = append(dependencies[clause.Key], dep.Key())
+			if depClause := contract.GetClause(dep.Key()); depClause != nil {
+				visit(depClause)
+			}
+		}
+	}
+	for _, clause := range targets {
+		visit(clause)
+	}
+
+	sorted, cyclicKeys := utils.SortDependencies(keys, dependencies)
+	for _, key := range sorted {
+		order = append(order, contract.GetClause(key))
+	}
+	for _, key := range cyclicKeys {
+		cyclic = append(cyclic, contract.GetClause(key))
+	}
+
+	return order, cyclic
+}
+
+// expireContract moves a contract past its end date to expired and returns it without executing its clauses
+func expireContract(stub *sw.StubWrapper, contract *models.AutoExecutableContract) ([]byte, errors.ICCError) {
+	updatedContract, err := contract.Asset.Update(stub, map[string]interface{}{
+		"status": datatypes.ContractExpired,
+	})
+	if err != nil {
+		return nil, errors.WrapError(err, "Failed to expire contract")
+	}
+
+	err = eventtypes.Emit(stub, eventtypes.ContractStatusChanged, eventtypes.ContractEvent{
+		Contract: contract.Key,
+		Name:     contract.Name,
+		Status:   datatypes.ContractExpired.String(),
+	})
+	if err != nil {
+		return nil, errors.WrapError(err, "Failed to emit contract status event")
+	}
+
+	responseJSON, nerr := json.Marshal(updatedContract)
+	if nerr != nil {
+		return nil, errors.WrapError(nerr, "failed to encode response to JSON format")
+	}
+
+	return responseJSON, nil
+}
+
+// clauseRun is the outcome of a clause action, computed without writing to the ledger
+type clauseRun struct {
+	recordedInputs map[string]interface{}
+	result         *models.Result
+	finalize       bool
+	status         datatypes.ContractStatusType
+}
+
+// runClause executes the clause action without writing to the ledger, so that a failure only skips
+// the clause. It returns nil and no error when the clause is not executed because it is finalized
+// or not executable.
+func runClause(stub *sw.StubWrapper, contract *models.AutoExecutableContract, clause *models.Clause, opts executionOptions) (*clauseRun, errors.ICCError) {
+	if clause.Finalized || !clause.Executable {
+		return nil, nil
+	}
+
 	for _, dep := range clause.Dependencies {
-		depClause := contract.GetClause(dep.Key())
-		if depClause == nil {
-			return errors.NewCCError("Clause does not belong to contract", http.StatusBadRequest)
+		if contract.GetClause(dep.Key()) == nil {
+			return nil, errors.NewCCError(fmt.Sprintf("Dependency %s does not belong to contract", dep.Key()), http.StatusBadRequest)
 		}
+	}
+
+	inputs := utils.JoinMaps(nil, clause.Input, clause.Parameters)
+	inputs = utils.JoinMaps(inputs, contract.Data, opts.inputs[clause.Key])
 
-		err := ExecuteClause(stub, contract, depClause)
+	// Actions that depend on the current date use the transaction timestamp, which is the same on every peer
+	if opts.asOf != "" {
+		inputs["executionDate"] = opts.asOf
+	} else {
+		now, err := txTime(stub)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		inputs["executionDate"] = now.Format(time.RFC3339)
 	}
 
-	inputs := utils.JoinMaps(clause.Input, clause.Parameters, contract.Data)
-
 	action := params.Get(clause.ActionType)
+	if loader, ok := action.(params.LedgerLoader); ok {
+		err := loader.LoadInputs(stub, inputs)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to load action inputs")
+		}
+	}
+
+	// The execution record keeps the inputs exactly as the action receives them, since the action
+	// may change them while executing
+	recordedInputs, nerr := copyData(inputs)
+	if nerr != nil {
+		return nil, errors.WrapError(nerr, "Failed to copy clause inputs")
+	}
+
+	// Payment details left on clauses and data not yet migrated to the paymentDetails collection are never copied
+	removePaymentDetails(recordedInputs)
+
 	result, shouldFinalizeClause, err := action.Execute(inputs, contract.Data)
 	if err != nil {
-		return errors.WrapError(err, "Failed to execute action")
+		return nil, errors.WrapError(err, "Failed to execute action")
 	}
 
-	if len(result.Assets) > 0 {
-		err = utils.SaveGeneratedAssets(stub, result.Assets, contract, clause)
+	// Finishing a finish contract clause moves the contract to its terminal status
+	status := contract.Status
+	switch result.Meta["contractStatus"] {
+	case params.ContractFinalized:
+		status = datatypes.ContractFinalized
+	case params.ContractCancelled:
+		status = datatypes.ContractCancelled
+	}
+	if !opts.dryRun && status != contract.Status {
+		err = checkTransition(contract.Status, status)
 		if err != nil {
-			return errors.WrapError(err, "Failed to save generated assets")
+			return nil, err
+		}
+	}
+
+	return &clauseRun{
+		recordedInputs: recordedInputs,
+		result:         result,
+		finalize:       shouldFinalizeClause,
+		status:         status,
+	}, nil
+}
+
+// applyClauseRun writes the generated assets, the contract status, the execution record and the clause
+// result to the ledger, or only updates the contract and clause in memory on a dry run
+func applyClauseRun(stub *sw.StubWrapper, contract *models.AutoExecutableContract, clause *models.Clause, run *clauseRun, opts executionOptions) (*ClauseResult, errors.ICCError) {
+	result := run.result
+	clauseResult := &ClauseResult{
+		Clause:    clause.Key,
+		Id:        clause.Id,
+		Success:   result.Success,
+		Feedback:  result.Feedback,
+		Finalized: run.finalize,
+		Meta:      result.Meta,
+	}
+
+	var generated []*assets.Asset
+	var err errors.ICCError
+	if len(result.Assets) > 0 {
+		if opts.dryRun {
+			generated, err = utils.GenerateAssets(result.Assets, contract, clause)
+			if err != nil {
+				return nil, errors.WrapError(err, "Failed to generate assets")
+			}
+			for _, a := range generated {
+				clauseResult.Assets = append(clauseResult.Assets, *a)
+			}
+		} else {
+			generated, err = utils.SaveGeneratedAssets(stub, result.Assets, contract, clause)
+			if err != nil {
+				return nil, errors.WrapError(err, "Failed to save generated assets")
+			}
 		}
 	}
 
 	contract.Data = mergeData(contract.Data, result.Data)
+	if len(generated) > 0 {
+		contract.Data = utils.RecordGeneratedAssets(contract.Data, generated)
+	}
+
+	if opts.dryRun {
+		clause.Finalized = run.finalize
+		contract.Status = run.status
+		return clauseResult, nil
+	}
+
+	if run.status != contract.Status {
+		_, err = contract.Asset.Update(stub, map[string]interface{}{
+			"status": run.status,
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to update contract status")
+		}
+		contract.Status = run.status
+	}
+
+	err = saveClauseExecution(stub, contract, clause, run.recordedInputs, result, run.finalize)
+	if err != nil {
+		return nil, err
+	}
+
+	return clauseResult, updateClause(stub, clause, run.finalize, result.Success, result.Feedback)
+}
+
+// saveClauseExecution keeps a record of the execution, as the clause only holds its latest result
+func saveClauseExecution(stub *sw.StubWrapper, contract *models.AutoExecutableContract, clause *models.Clause, inputs map[string]interface{}, result *models.Result, shouldFinalize bool) errors.ICCError {
+	now, err := txTime(stub)
+	if err != nil {
+		return err
+	}
+
+	// Copies are stored since saving an object adds an "@assetType" entry to it
+	inputsCopy, nerr := copyData(inputs)
+	if nerr != nil {
+		return errors.WrapError(nerr, "Failed to copy clause inputs")
+	}
+	resultData, nerr := copyData(result.Data)
+	if nerr != nil {
+		return errors.WrapError(nerr, "Failed to copy result data")
+	}
+	meta, nerr := copyData(result.Meta)
+	if nerr != nil {
+		return errors.WrapError(nerr, "Failed to copy result meta")
+	}
+
+	execution, err := assets.NewAsset(map[string]interface{}{
+		"@assetType": "clauseExecution",
+		"txId":       stub.Stub.GetTxID(),
+		"clause": map[string]interface{}{
+			"@assetType": "clause",
+			"@key":       clause.Key,
+		},
+		"autoExecutableContract": map[string]interface{}{
+			"@assetType": "autoExecutableContract",
+			"@key":       contract.Key,
+		},
+		"timestamp":      now,
+		"actionType":     clause.ActionType,
+		"inputs":         inputsCopy,
+		"resultData":     resultData,
+		"meta":           meta,
+		"success":        result.Success,
+		"feedback":       result.Feedback,
+		"shouldFinalize": shouldFinalize,
+	})
+	if err != nil {
+		return errors.WrapError(err, "Failed to create clause execution asset")
+	}
+
+	_, err = execution.PutNew(stub)
+	if err != nil {
+		return errors.WrapError(err, "Failed to save clause execution on ledger")
+	}
 
-	return updateClause(stub, clause, shouldFinalizeClause, result.Success, result.Feedback)
+	return nil
+}
+
+// txTime returns the transaction timestamp, which is the same on every peer
+func txTime(stub *sw.StubWrapper) (time.Time, errors.ICCError) {
+	txTimestamp, err := stub.Stub.GetTxTimestamp()
+	if err != nil {
+		return time.Time{}, errors.WrapError(err, "Failed to get transaction timestamp")
+	}
+	return time.Unix(txTimestamp.Seconds, int64(txTimestamp.Nanos)).UTC(), nil
 }
 
 func updateClause(stub *sw.StubWrapper, clause *models.Clause, shouldFinalize bool, success bool, feedback string) errors.ICCError {
@@ -0,0 +1,75 @@
+package contract
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+var GetClauseExecutions = tx.Transaction{
+	Tag:         "getClauseExecutions",
+	Label:       "Get Clause Executions",
+	Description: "Lists the executions of a contract or of a clause, oldest first",
+	Method:      "GET",
+	ReadOnly:    true,
+
+	Args: []tx.Argument{
+		{
+			Tag:      "contract",
+			Label:    "Contract",
+			DataType: "->autoExecutableContract",
+		},
+		{
+			Tag:      "clause",
+			Label:    "Clause",
+			DataType: "->clause",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		selector := map[string]interface{}{
+			"@assetType": "clauseExecution",
+		}
+
+		contractKey, hasContract := req["contract"].(assets.Key)
+		if hasContract {
+			selector["autoExecutableContract.@key"] = contractKey.Key()
+		}
+
+		clauseKey, hasClause := req["clause"].(assets.Key)
+		if hasClause {
+			selector["clause.@key"] = clauseKey.Key()
+		}
+
+		if !hasContract && !hasClause {
+			return nil, errors.NewCCError("Either 'contract' or 'clause' must be provided", http.StatusBadRequest)
+		}
+
+		query := map[string]interface{}{
+			"selector": selector,
+		}
+
+		response, err := assets.Search(stub, query, "", false)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "error searching for clause executions", http.StatusInternalServerError)
+		}
+
+		executions := response.Result
+		sort.SliceStable(executions, func(i, j int) bool {
+			timestampI, _ := executions[i]["timestamp"].(string)
+			timestampJ, _ := executions[j]["timestamp"].(string)
+			return timestampI < timestampJ
+		})
+
+		responseJSON, nerr := json.Marshal(executions)
+		if nerr != nil {
+			return nil, errors.WrapErrorWithStatus(nerr, "error marshaling response", http.StatusInternalServerError)
+		}
+
+		return responseJSON, nil
+	},
+}
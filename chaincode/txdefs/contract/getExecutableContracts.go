@@ -8,6 +8,8 @@ import (
 	"github.com/hyperledger-labs/cc-tools/errors"
 	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
 	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/txdefs/contract/models"
 )
 
 var ContractsWithExecutableClauses = tx.Transaction{
@@ -15,11 +17,13 @@ var ContractsWithExecutableClauses = tx.Transaction{
 	Label:       "Contracts with Executable Clauses",
 	Description: "Retrieves all contracts containing clauses that are executable but not finalized",
 	Method:      "GET",
+	ReadOnly:    true,
 
 	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
 		query := map[string]interface{}{
 			"selector": map[string]interface{}{
 				"@assetType": "autoExecutableContract",
+				"$or":        activeContractSelector(),
 			},
 		}
 
@@ -31,6 +35,10 @@ var ContractsWithExecutableClauses = tx.Transaction{
 		var filteredContracts []map[string]interface{}
 
 		for _, item := range response.Result {
+			// Only active contracts are executed
+			if models.ContractStatus(item) != datatypes.ContractActive {
+				continue
+			}
 
 			clauses, ok := item["clauses"].([]interface{})
 			if !ok {
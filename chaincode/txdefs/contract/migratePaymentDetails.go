@@ -0,0 +1,241 @@
+package contract
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+var MigratePaymentDetails = tx.Transaction{
+	Tag:         "migratePaymentDetails",
+	Label:       "Migrate Payment Details",
+	Description: "Moves the receipt URLs and payment provider identifiers recorded before they were private from the contract payment and installments clauses, data and payments to the paymentDetails collection, keeping only their salted hash. Values already written remain in the ledger history",
+	Method:      "POST",
+
+	Args: []tx.Argument{
+		{
+			Required: true,
+			Tag:      "autoExecutableContract",
+			Label:    "Auto Executable Contract",
+			DataType: "->autoExecutableContract",
+		},
+		{
+			Required:    true,
+			Tag:         "salt",
+			Label:       "Salt",
+			DataType:    "string",
+			Private:     true,
+			Description: "Random value hashed with the migrated payment details",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		contractKey, ok := req["autoExecutableContract"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter 'autoExecutableContract' must be an asset key")
+		}
+
+		salt, _ := req["salt"].(string)
+
+		contract, err := contractKey.Get(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get autoExecutableContract asset from ledger")
+		}
+
+		err = checkOwner(stub, *contract)
+		if err != nil {
+			return nil, err
+		}
+
+		data, _ := (*contract)["data"].(map[string]interface{})
+		migrated := 0
+		dataChanged := false
+
+		for _, key := range utils.RefKeys((*contract)["clauses"]) {
+			clauseKey := assets.Key{"@assetType": "clause", "@key": key}
+			clause, err := clauseKey.Get(stub)
+			if err != nil {
+				return nil, errors.WrapError(err, "Failed to get clause")
+			}
+			actionType, _ := datatypes.ParseActionType((*clause)["actionType"])
+			switch actionType {
+			case datatypes.Payment:
+				input, _ := (*clause)["input"].(map[string]interface{})
+				moved, err := migratePaymentFields(stub, *contract, clauseKey, input, salt)
+				if err != nil {
+					return nil, err
+				}
+				if moved {
+					_, err = clauseKey.Update(stub, map[string]interface{}{
+						"input": input,
+					})
+					if err != nil {
+						return nil, errors.WrapError(err, "Failed to update clause")
+					}
+					migrated++
+				}
+
+				// Executions copied the inputs to the contract data under the payment name
+				parameters, _ := (*clause)["parameters"].(map[string]interface{})
+				name, _ := parameters["name"].(string)
+				paymentData, _ := data[name].(map[string]interface{})
+				moved, err = migratePaymentFields(stub, *contract, clauseKey, paymentData, salt)
+				if err != nil {
+					return nil, err
+				}
+				if moved {
+					dataChanged = true
+					migrated++
+				}
+
+			case datatypes.Installments:
+				input, _ := (*clause)["input"].(map[string]interface{})
+				payments, _ := input["payments"].([]interface{})
+				moved, err := migrateEachPayment(stub, *contract, clauseKey, payments, salt)
+				if err != nil {
+					return nil, err
+				}
+				if moved > 0 {
+					_, err = clauseKey.Update(stub, map[string]interface{}{
+						"input": input,
+					})
+					if err != nil {
+						return nil, errors.WrapError(err, "Failed to update clause")
+					}
+					migrated += moved
+				}
+
+				// Executions copied the payments to the receipts of the installments in the contract data
+				parameters, _ := (*clause)["parameters"].(map[string]interface{})
+				name, _ := parameters["name"].(string)
+				if name == "" {
+					name = "installments"
+				}
+				schedule, _ := data[name].(map[string]interface{})
+				installments, _ := schedule["installments"].([]interface{})
+				for _, i := range installments {
+					installment, _ := i.(map[string]interface{})
+					receipts, _ := installment["receipts"].([]interface{})
+					moved, err := migrateEachPayment(stub, *contract, clauseKey, receipts, salt)
+					if err != nil {
+						return nil, err
+					}
+					if moved > 0 {
+						dataChanged = true
+						migrated += moved
+					}
+				}
+			}
+		}
+
+		if dataChanged {
+			_, err = contractKey.Update(stub, map[string]interface{}{
+				"data": data,
+			})
+			if err != nil {
+				return nil, errors.WrapError(err, "Failed to update contract data")
+			}
+		}
+
+		query := map[string]interface{}{
+			"selector": map[string]interface{}{
+				"@assetType":                  "payment",
+				"autoExecutableContract.@key": contractKey.Key(),
+				"receiptUrl":                  map[string]interface{}{"$exists": true},
+			},
+		}
+
+		response, err := assets.Search(stub, query, "", true)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "error searching for contract payments", http.StatusInternalServerError)
+		}
+
+		for _, payment := range response.Result {
+			clauseRef, _ := payment["clause"].(map[string]interface{})
+			clauseKey := assets.Key{"@assetType": "clause", "@key": clauseRef["@key"]}
+			moved, err := migratePaymentFields(stub, *contract, clauseKey, payment, salt)
+			if err != nil {
+				return nil, err
+			}
+			if !moved {
+				continue
+			}
+
+			// Update merges fields, so the payment is rewritten without the receipt URL
+			rewritten := map[string]interface{}{
+				"@assetType": "payment",
+			}
+			for prop, value := range payment {
+				if !strings.HasPrefix(prop, "@") {
+					rewritten[prop] = value
+				}
+			}
+			paymentAsset, err := assets.NewAsset(rewritten)
+			if err != nil {
+				return nil, errors.WrapError(err, "Failed to create payment asset")
+			}
+			_, err = paymentAsset.Put(stub)
+			if err != nil {
+				return nil, errors.WrapError(err, "Failed to update payment")
+			}
+			migrated++
+		}
+
+		responseJSON, nerr := json.Marshal(map[string]interface{}{
+			"autoExecutableContract": contractKey.Key(),
+			"migrated":               migrated,
+		})
+		if nerr != nil {
+			return nil, errors.WrapErrorWithStatus(nerr, "Failed to marshal response to JSON format", http.StatusInternalServerError)
+		}
+
+		return responseJSON, nil
+	},
+}
+
+// migrateEachPayment migrates the payment details of every payment in the list and returns how many
+// payments had details to move
+func migrateEachPayment(stub *sw.StubWrapper, contract assets.Asset, clauseKey assets.Key, payments []interface{}, salt string) (int, errors.ICCError) {
+	migrated := 0
+	for _, p := range payments {
+		payment, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		moved, err := migratePaymentFields(stub, contract, clauseKey, payment, salt)
+		if err != nil {
+			return 0, err
+		}
+		if moved {
+			migrated++
+		}
+	}
+	return migrated, nil
+}
+
+// migratePaymentFields moves the payment details found on values to the private collection and
+// replaces them with their salted hash. It tells whether values had payment details to move.
+func migratePaymentFields(stub *sw.StubWrapper, contract assets.Asset, clauseKey assets.Key, values map[string]interface{}, salt string) (bool, errors.ICCError) {
+	details := paymentDetails(values)
+	if details == nil {
+		return false, nil
+	}
+
+	hash, err := putPaymentDetails(stub, contract, clauseKey, utils.JoinMaps(nil, details, map[string]interface{}{"salt": salt}))
+	if err != nil {
+		return false, err
+	}
+
+	for _, field := range paymentDetailsFields {
+		delete(values, field)
+	}
+	values["detailsHash"] = hash
+
+	return true, nil
+}
@@ -6,16 +6,19 @@ import (
 	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/errors"
 	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
 )
 
 type AutoExecutableContract struct {
-	Key           string                 `json:"key"`
-	Name          string                 `json:"name"`
-	SignatureDate string                 `json:"signatureDate"`
-	Clauses       []*Clause              `json:"clauses"`
-	Data          map[string]interface{} `json:"data"`
-	Owner         assets.Key             `json:"owner"`
-	Participants  []assets.Key           `json:"participants"`
+	Key           string                       `json:"key"`
+	Name          string                       `json:"name"`
+	SignatureDate string                       `json:"signatureDate"`
+	Clauses       []*Clause                    `json:"clauses"`
+	Data          map[string]interface{}       `json:"data"`
+	Owner         assets.Key                   `json:"owner"`
+	Participants  []assets.Key                 `json:"participants"`
+	Status        datatypes.ContractStatusType `json:"status"`
+	EndDate       string                       `json:"endDate,omitempty"`
 	Asset         *assets.Asset
 }
 
@@ -52,11 +55,18 @@ func GetAutoExecutableContract(stub *sw.StubWrapper, key assets.Key) (*AutoExecu
 	contract.Key, _ = asset.GetProp("@key").(string)
 	contract.Name, _ = asset.GetProp("name").(string)
 
-	signatureDate, _ := asset.GetProp("signatureDate").(time.Time)
-	contract.SignatureDate = signatureDate.Format(time.RFC3339)
+	// Contracts waiting for their document to be signed have no signature date yet
+	if signatureDate, ok := ParseTime(asset.GetProp("signatureDate")); ok {
+		contract.SignatureDate = signatureDate.Format(time.RFC3339)
+	}
 
 	contract.Data = data
 
+	contract.Status = ContractStatus(*asset)
+	if endDate, ok := ParseTime(asset.GetProp("endDate")); ok {
+		contract.EndDate = endDate.Format(time.RFC3339)
+	}
+
 	ownerKey, _ := asset.GetProp("owner").(map[string]interface{})
 	contract.Owner, _ = assets.NewKey(ownerKey)
 
@@ -75,6 +85,15 @@ func GetAutoExecutableContract(stub *sw.StubWrapper, key assets.Key) (*AutoExecu
 	return &contract, nil
 }
 
+// ContractStatus returns the status of a contract asset. Contracts created before statuses existed are active.
+func ContractStatus(contract map[string]interface{}) datatypes.ContractStatusType {
+	status, ok := datatypes.ParseContractStatus(contract["status"])
+	if !ok {
+		return datatypes.ContractActive
+	}
+	return status
+}
+
 func (c *AutoExecutableContract) GetClause(key string) *Clause {
 	for i := range c.Clauses {
 		if c.Clauses[i].Key == key {
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// ParseTime reads a datetime property of an asset map, which is a time.Time once parsed by
+// cc-tools and an RFC3339 string when the asset is read back as raw JSON
+func ParseTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return t, true
+	default:
+		return time.Time{}, false
+	}
+}
@@ -115,6 +115,7 @@ func (a *CalculateCredit) Execute(input interface{}, data map[string]interface{}
 				Success:  true,
 				Feedback: feedback,
 				Data:     updateData,
+				Assets:   creditAssets(creditAmount, parameters.CreditName),
 			}, true, nil
 		}
 
@@ -141,6 +142,7 @@ func (a *CalculateCredit) Execute(input interface{}, data map[string]interface{}
 				Success:  true,
 				Feedback: feedback,
 				Data:     updateData,
+				Assets:   creditAssets(creditAmount, parameters.CreditName),
 			}, true, nil
 		}
 	}
@@ -152,6 +154,17 @@ func (a *CalculateCredit) Execute(input interface{}, data map[string]interface{}
 	}, false, nil
 }
 
+// creditAssets returns the credit generated by the clause. It is linked to the contract and clause when it is generated.
+func creditAssets(creditAmount float64, creditName string) []map[string]interface{} {
+	return []map[string]interface{}{
+		{
+			"@assetType":  "credit",
+			"description": creditName,
+			"value":       creditAmount,
+		},
+	}
+}
+
 func updateBonusData(data map[string]interface{}, creditAmount float64, creditName, feedback string) map[string]interface{} {
 	// Update the "bonus" field if it exists
 	if currentBonus, exists := data["bonus"]; exists {
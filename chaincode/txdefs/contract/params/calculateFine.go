@@ -92,63 +92,81 @@ func (a *CalculateFine) Execute(input interface{}, data map[string]interface{})
 		}
 	}
 
-	// Calculate the fine
-	fine := fineInput.ReferenceValue * fineInput.DailyPercentage / 100 * days
+	// Calculate the fine, applying the upper limit if necessary
+	fine := computeFine(fineInput.ReferenceValue, fineInput.DailyPercentage, days, fineParams.MaxPercentage, fineParams.MaxReferenceValue)
 
-	// Apply upper limit if necessary
-	var shouldConsiderUpperLimit bool
-	if fineParams.MaxPercentage > 0 && fineParams.MaxReferenceValue > 0 {
-		shouldConsiderUpperLimit = true
+	// Handle empty fine name
+	if fineParams.FineName == "" {
+		fineParams.FineName = fineName
 	}
 
-	if shouldConsiderUpperLimit {
-		limit := fineParams.MaxPercentage / 100 * fineParams.MaxReferenceValue * days
-		if fine > limit {
-			fine = limit
+	updateData := addFine(data, fineParams.FineName, fine, "Fine calculated successfully.")
+
+	// Prepare the result with updated data
+	result := &models.Result{
+		Success:  true,
+		Feedback: "Fine calculated successfully. The applied fine is " + fmt.Sprintf("%.2f", fine),
+		Data:     updateData,
+	}
+
+	// The deduction is linked to the contract and clause when it is generated
+	if fine > 0 {
+		result.Assets = []map[string]interface{}{
+			{
+				"@assetType":  "deduction",
+				"description": fineParams.FineName,
+				"value":       fine,
+			},
 		}
 	}
 
-	// Handle empty fine name
-	if fineParams.FineName == "" {
-		fineParams.FineName = fineName
+	return result, true, nil
+}
+
+// computeFine applies the daily percentage over the reference value for the given days,
+// limited by the maximum percentage of the maximum reference value when both are set
+func computeFine(referenceValue, dailyPercentage, days, maxPercentage, maxReferenceValue float64) float64 {
+	fine := referenceValue * dailyPercentage / 100 * days
+
+	if maxPercentage > 0 && maxReferenceValue > 0 {
+		limit := maxPercentage / 100 * maxReferenceValue * days
+		if fine > limit {
+			fine = limit
+		}
 	}
 
-	updateData := data
+	return fine
+}
 
+// addFine adds the fine to the "fine" total of the contract data and to its "listOfFines"
+func addFine(data map[string]interface{}, name string, fine float64, feedback string) map[string]interface{} {
 	// Add fine to the "fine" field if it exists
-	if currentFine, exists := updateData["fine"]; exists {
+	if currentFine, exists := data["fine"]; exists {
 		if fineValue, ok := currentFine.(float64); ok {
-			updateData["fine"] = fineValue + fine
+			data["fine"] = fineValue + fine
 		}
 	} else {
-		updateData["fine"] = fine
+		data["fine"] = fine
 	}
 
 	// Add the current fine to the "listOfFines" field
 	newFineEntry := map[string]interface{}{
-		"name":     fineParams.FineName,
+		"name":     name,
 		"fine":     fine,
-		"feedback": "Fine calculated successfully.",
+		"feedback": feedback,
 		"success":  true,
 	}
 
-	if listOfFines, exists := updateData["listOfFines"]; exists {
+	if listOfFines, exists := data["listOfFines"]; exists {
 		if fines, ok := listOfFines.([]interface{}); ok {
 			fines = append(fines, newFineEntry)
-			updateData["listOfFines"] = fines
+			data["listOfFines"] = fines
 		} else {
-			updateData["listOfFines"] = []interface{}{newFineEntry}
+			data["listOfFines"] = []interface{}{newFineEntry}
 		}
 	} else {
-		updateData["listOfFines"] = []interface{}{newFineEntry}
+		data["listOfFines"] = []interface{}{newFineEntry}
 	}
 
-	// Prepare the result with updated data
-	result := &models.Result{
-		Success:  true,
-		Feedback: "Fine calculated successfully. The applied fine is " + fmt.Sprintf("%.2f", fine),
-		Data:     updateData,
-	}
-
-	return result, true, nil
+	return data
 }
@@ -3,19 +3,24 @@ package params
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
 	"time"
+	_ "time/tzdata" // the chaincode container may not ship the time zone database
 
+	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
 	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
 	"github.com/hyperledger-labs/clausia-cc/chaincode/txdefs/contract/models"
 )
 
 const (
-	intervalDays   int = 1
-	intervalWeeks  int = 2
-	intervalMonths int = 3
-	intervalYears  int = 4
+	intervalDays         int = 1
+	intervalWeeks        int = 2
+	intervalMonths       int = 3
+	intervalYears        int = 4
+	intervalBusinessDays int = 5
 
 	intervalBefore int = -1
 	intervalAfter  int = 1
@@ -27,15 +32,20 @@ type CheckDateInterval struct {
 }
 
 type ParametersCheckDateInterval struct {
-	Name             string `json:"name"`
-	IntervalType     int    `json:"intervalType"`
-	DeadlineInterval int    `json:"deadlineInterval"`
-	ReferenceDate    string `json:"referenceDate"` // Optional
+	Name             string   `json:"name"`
+	IntervalType     int      `json:"intervalType"` // before (-1) or after (1) the reference date
+	IntervalUnit     int      `json:"intervalUnit"` // days (default), weeks, months, years or business days
+	DeadlineInterval int      `json:"deadlineInterval"`
+	ReferenceDate    string   `json:"referenceDate"` // Optional
+	Timezone         string   `json:"timezone"`      // Optional, e.g. America/Sao_Paulo. Deadlines end at midnight of this zone
+	Calendars        []string `json:"calendars"`     // Optional holiday calendars used to count business days
 }
 
 type InputsCheckDateInterval struct {
-	ReferenceDate string `json:"referenceDate"` // Optional
-	EvaluatedDate string `json:"evaluatedDate"`
+	ReferenceDate     string   `json:"referenceDate"` // Optional
+	EvaluatedDate     string   `json:"evaluatedDate"`
+	Holidays          []string `json:"holidays"`          // Loaded from the holiday calendars
+	RecurringHolidays []string `json:"recurringHolidays"` // Loaded from the holiday calendars
 }
 
 func (a *CheckDateInterval) Type() datatypes.ActionType {
@@ -112,26 +122,41 @@ func (a *CheckDateInterval) Execute(input interface{}, data map[string]interface
 		return nil, false, errors.WrapError(err, "Failed to parse evaluated date")
 	}
 
-	// Calculate the deadline in days based on IntervalType
-	days := args.DeadlineInterval
-	switch args.IntervalType {
-	case intervalWeeks:
-		days *= 7
-	case intervalMonths:
-		days *= 30
-	case intervalYears:
-		days *= 365
+	if args.IntervalType != intervalBefore && args.IntervalType != intervalAfter {
+		return nil, false, errors.NewCCError("Invalid interval type, it must be 'before' (-1) or 'after'(1)", http.StatusBadRequest)
+	}
+
+	// Deadlines counted in calendar days end at midnight of the contract time zone.
+	// Without a time zone and business days the exact reference instant is kept.
+	loc := time.UTC
+	if args.Timezone != "" {
+		loc, err = time.LoadLocation(args.Timezone)
+		if err != nil {
+			return nil, false, errors.WrapErrorWithStatus(err, "Invalid timezone", http.StatusBadRequest)
+		}
+	}
+	wholeDays := args.Timezone != "" || args.IntervalUnit == intervalBusinessDays
+	if wholeDays {
+		refDate = startOfDay(refDate, loc)
+		evaluatedDate = startOfDay(evaluatedDate, loc)
 	}
 
-	// Calculate the interval date based on IntervalType
+	// Calculate the interval date with calendar arithmetic
+	n := args.DeadlineInterval * args.IntervalType
 	var intervalDate time.Time
-	switch args.IntervalType {
-	case intervalBefore:
-		intervalDate = refDate.AddDate(0, 0, -days)
-	case intervalAfter:
-		intervalDate = refDate.AddDate(0, 0, days)
+	switch args.IntervalUnit {
+	case 0, intervalDays:
+		intervalDate = refDate.AddDate(0, 0, n)
+	case intervalWeeks:
+		intervalDate = refDate.AddDate(0, 0, 7*n)
+	case intervalMonths:
+		intervalDate = addMonthsClamped(refDate, n)
+	case intervalYears:
+		intervalDate = addMonthsClamped(refDate, 12*n)
+	case intervalBusinessDays:
+		intervalDate = addBusinessDays(refDate, n, newHolidays(inputData.Holidays, inputData.RecurringHolidays))
 	default:
-		return nil, false, errors.NewCCError("Invalid interval type, it must be 'before' (-1) or 'after'(1)", http.StatusBadRequest)
+		return nil, false, errors.NewCCError("Invalid interval unit", http.StatusBadRequest)
 	}
 
 	// Determine if the evaluated date is within the deadline
@@ -139,6 +164,10 @@ func (a *CheckDateInterval) Execute(input interface{}, data map[string]interface
 	feedback := a.getFeedback(isWithinDeadline)
 
 	daysFromDeadline := int(intervalDate.Sub(evaluatedDate).Hours() / 24)
+	if wholeDays {
+		// Days around a daylight saving change are not exactly 24 hours long
+		daysFromDeadline = int(math.Round(intervalDate.Sub(evaluatedDate).Hours() / 24))
+	}
 
 	var deadlineInfo string
 	if daysFromDeadline > 0 {
@@ -166,6 +195,55 @@ func (a *CheckDateInterval) Execute(input interface{}, data map[string]interface
 
 }
 
+// LoadInputs reads the holidays of the calendars used to count business days
+func (a *CheckDateInterval) LoadInputs(stub *sw.StubWrapper, inputs map[string]interface{}) errors.ICCError {
+	inputBytes, err := json.Marshal(inputs)
+	if err != nil {
+		return errors.WrapError(err, "Failed to marshal input")
+	}
+
+	var args ParametersCheckDateInterval
+	err = json.Unmarshal(inputBytes, &args)
+	if err != nil {
+		return errors.WrapError(err, "Failed to unmarshal to CheckDateIntervalArgs")
+	}
+
+	var dates, recurring []interface{}
+	for _, name := range args.Calendars {
+		calendarKey, err := assets.NewKey(map[string]interface{}{
+			"@assetType": "holidayCalendar",
+			"name":       name,
+		})
+		if err != nil {
+			return errors.WrapError(err, "Failed to make holiday calendar key")
+		}
+
+		calendar, err := calendarKey.Get(stub)
+		if err != nil {
+			return errors.WrapError(err, fmt.Sprintf("Failed to get holiday calendar %s", name))
+		}
+
+		holidayDates, _ := (*calendar)["holidays"].([]interface{})
+		for _, d := range holidayDates {
+			switch date := d.(type) {
+			case time.Time:
+				dates = append(dates, date.Format(time.RFC3339))
+			case string:
+				dates = append(dates, date)
+			}
+		}
+		if r, ok := (*calendar)["recurringHolidays"].([]interface{}); ok {
+			recurring = append(recurring, r...)
+		}
+	}
+
+	if len(args.Calendars) > 0 {
+		inputs["holidays"] = dates
+		inputs["recurringHolidays"] = recurring
+	}
+	return nil
+}
+
 func (a *CheckDateInterval) GetParameters() interface{} {
 	return ParametersCheckDateInterval{}
 }
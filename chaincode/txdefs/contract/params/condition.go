@@ -0,0 +1,388 @@
+package params
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools/errors"
+)
+
+// Condition is a boolean expression evaluated against the contract data.
+// Groups (and, or, not) can be nested, and every leaf compares the value found
+// at a dotted path of the data (e.g. "payment.dateIntervalCheck.success") with a reference value.
+type Condition struct {
+	And []Condition `json:"and,omitempty"`
+	Or  []Condition `json:"or,omitempty"`
+	Not *Condition  `json:"not,omitempty"`
+
+	Path             string           `json:"path,omitempty"`
+	ConditionalCheck ConditionalCheck `json:"conditionalCheck,omitempty"`
+	ReferenceValue   interface{}      `json:"referenceValue,omitempty"`
+	DataType         DataType         `json:"dataType,omitempty"`
+}
+
+func (c *Condition) isLeaf() bool {
+	return c.Path != "" || c.ConditionalCheck != ""
+}
+
+// Evaluate returns whether the condition holds for the given data.
+// An error is only returned when the condition itself is malformed.
+func (c *Condition) Evaluate(data map[string]interface{}) (bool, errors.ICCError) {
+	if len(c.And) == 0 && len(c.Or) == 0 && c.Not == nil && !c.isLeaf() {
+		return false, errors.NewCCError("Empty condition", http.StatusBadRequest)
+	}
+
+	for i := range c.And {
+		met, err := c.And[i].Evaluate(data)
+		if err != nil || !met {
+			return false, err
+		}
+	}
+
+	if len(c.Or) > 0 {
+		anyMet := false
+		for i := range c.Or {
+			met, err := c.Or[i].Evaluate(data)
+			if err != nil {
+				return false, err
+			}
+			if met {
+				anyMet = true
+				break
+			}
+		}
+		if !anyMet {
+			return false, nil
+		}
+	}
+
+	if c.Not != nil {
+		met, err := c.Not.Evaluate(data)
+		if err != nil || met {
+			return false, err
+		}
+	}
+
+	if c.isLeaf() {
+		return c.evaluateLeaf(data)
+	}
+
+	return true, nil
+}
+
+func (c *Condition) evaluateLeaf(data map[string]interface{}) (bool, errors.ICCError) {
+	if c.Path == "" {
+		return false, errors.NewCCError("Condition path must be provided", http.StatusBadRequest)
+	}
+
+	value, exists := LookupPath(data, c.Path)
+
+	switch c.ConditionalCheck {
+	case Exists:
+		return exists && value != nil, nil
+	case NotExists:
+		return !exists || value == nil, nil
+	}
+
+	if !exists || value == nil {
+		return false, nil
+	}
+
+	dataType := c.DataType
+	if c.ConditionalCheck == Before || c.ConditionalCheck == After {
+		dataType = DateType
+	}
+
+	switch c.ConditionalCheck {
+	case Equal, NotEqual:
+		cmp, err := compareValues(value, c.ReferenceValue, dataType)
+		if err != nil {
+			return false, err
+		}
+		return (cmp == 0) == (c.ConditionalCheck == Equal), nil
+
+	case Greater, GreaterOrEqual, Smaller, SmallerOrEqual, Before, After:
+		if dataType == BoolType {
+			return false, errors.NewCCError(fmt.Sprintf("Conditional check '%s' is not supported for boolean values", c.ConditionalCheck), http.StatusBadRequest)
+		}
+		cmp, err := compareValues(value, c.ReferenceValue, dataType)
+		if err != nil {
+			return false, err
+		}
+		switch c.ConditionalCheck {
+		case Greater, After:
+			return cmp > 0, nil
+		case GreaterOrEqual:
+			return cmp >= 0, nil
+		case Smaller, Before:
+			return cmp < 0, nil
+		default:
+			return cmp <= 0, nil
+		}
+
+	case Between:
+		bounds, ok := c.ReferenceValue.([]interface{})
+		if !ok || len(bounds) != 2 {
+			return false, errors.NewCCError("Reference value for 'between' must be a list with a lower and an upper bound", http.StatusBadRequest)
+		}
+		lower, err := compareValues(value, bounds[0], dataType)
+		if err != nil {
+			return false, err
+		}
+		upper, err := compareValues(value, bounds[1], dataType)
+		if err != nil {
+			return false, err
+		}
+		return lower >= 0 && upper <= 0, nil
+
+	case In, NotIn:
+		options, ok := c.ReferenceValue.([]interface{})
+		if !ok {
+			return false, errors.NewCCError(fmt.Sprintf("Reference value for '%s' must be a list", c.ConditionalCheck), http.StatusBadRequest)
+		}
+		found := false
+		for _, option := range options {
+			cmp, err := compareValues(value, option, dataType)
+			if err == nil && cmp == 0 {
+				found = true
+				break
+			}
+		}
+		return found == (c.ConditionalCheck == In), nil
+
+	case Contains:
+		switch v := value.(type) {
+		case string:
+			ref, ok := c.ReferenceValue.(string)
+			if !ok {
+				return false, errors.NewCCError("Reference value for 'contains' on a string must be a string", http.StatusBadRequest)
+			}
+			return strings.Contains(v, ref), nil
+		case []interface{}:
+			for _, item := range v {
+				cmp, err := compareValues(item, c.ReferenceValue, dataType)
+				if err == nil && cmp == 0 {
+					return true, nil
+				}
+			}
+			return false, nil
+		default:
+			return false, nil
+		}
+
+	default:
+		return false, errors.NewCCError(fmt.Sprintf("Unsupported conditional check '%s'", c.ConditionalCheck), http.StatusBadRequest)
+	}
+}
+
+// LookupPath returns the value stored at a dotted path of a nested map.
+// A key containing dots is matched as a whole before the path is split.
+func LookupPath(data map[string]interface{}, path string) (interface{}, bool) {
+	if value, exists := data[path]; exists {
+		return value, true
+	}
+
+	var current interface{} = data
+	for _, segment := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, exists := node[segment]
+			if !exists {
+				return nil, false
+			}
+			current = value
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			current = node[index]
+		default:
+			// Structs and typed maps written by executors are walked through their JSON form
+			if node == nil {
+				return nil, false
+			}
+			bytes, err := json.Marshal(node)
+			if err != nil {
+				return nil, false
+			}
+			var generic map[string]interface{}
+			if json.Unmarshal(bytes, &generic) != nil {
+				return nil, false
+			}
+			value, exists := generic[segment]
+			if !exists {
+				return nil, false
+			}
+			current = value
+		}
+	}
+
+	return current, true
+}
+
+// compareValues returns -1, 0 or 1 when a is smaller, equal or greater than b.
+// When no data type is given it is inferred from the values.
+func compareValues(a, b interface{}, dataType DataType) (int, errors.ICCError) {
+	if dataType == "" {
+		dataType = inferDataType(a, b)
+	}
+
+	switch dataType {
+	case IntType, FloatType:
+		numA, ok := toFloat(a)
+		if !ok {
+			return 0, errors.NewCCError(fmt.Sprintf("Value %v must be a number", a), http.StatusBadRequest)
+		}
+		numB, ok := toFloat(b)
+		if !ok {
+			return 0, errors.NewCCError(fmt.Sprintf("Reference value %v must be a number", b), http.StatusBadRequest)
+		}
+		if dataType == IntType {
+			if numA != float64(int64(numA)) {
+				return 0, errors.NewCCError(fmt.Sprintf("Value %v must be an integer", a), http.StatusBadRequest)
+			}
+			if numB != float64(int64(numB)) {
+				return 0, errors.NewCCError(fmt.Sprintf("Reference value %v must be an integer", b), http.StatusBadRequest)
+			}
+		}
+		return compareFloats(numA, numB), nil
+
+	case DateType:
+		dateA, ok := toTime(a)
+		if !ok {
+			return 0, errors.NewCCError(fmt.Sprintf("Value %v must be a date", a), http.StatusBadRequest)
+		}
+		dateB, ok := toTime(b)
+		if !ok {
+			return 0, errors.NewCCError(fmt.Sprintf("Reference value %v must be a date", b), http.StatusBadRequest)
+		}
+		switch {
+		case dateA.Before(dateB):
+			return -1, nil
+		case dateA.After(dateB):
+			return 1, nil
+		default:
+			return 0, nil
+		}
+
+	case BoolType:
+		boolA, ok := toBool(a)
+		if !ok {
+			return 0, errors.NewCCError(fmt.Sprintf("Value %v must be a boolean", a), http.StatusBadRequest)
+		}
+		boolB, ok := toBool(b)
+		if !ok {
+			return 0, errors.NewCCError(fmt.Sprintf("Reference value %v must be a boolean", b), http.StatusBadRequest)
+		}
+		if boolA == boolB {
+			return 0, nil
+		}
+		return 1, nil
+
+	case StringType:
+		strA, ok := a.(string)
+		if !ok {
+			return 0, errors.NewCCError(fmt.Sprintf("Value %v must be a string", a), http.StatusBadRequest)
+		}
+		strB, ok := b.(string)
+		if !ok {
+			return 0, errors.NewCCError(fmt.Sprintf("Reference value %v must be a string", b), http.StatusBadRequest)
+		}
+		return strings.Compare(strA, strB), nil
+
+	default:
+		return 0, errors.NewCCError(fmt.Sprintf("Unsupported data type '%s'", dataType), http.StatusBadRequest)
+	}
+}
+
+func inferDataType(a, b interface{}) DataType {
+	_, aIsNumber := toFloat(a)
+	_, bIsNumber := toFloat(b)
+	_, aIsString := a.(string)
+	_, bIsString := b.(string)
+	if aIsNumber && bIsNumber && !(aIsString && bIsString) {
+		return FloatType
+	}
+
+	_, aIsBool := a.(bool)
+	_, bIsBool := b.(bool)
+	if aIsBool || bIsBool {
+		return BoolType
+	}
+
+	return StringType
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// toFloat coerces JSON numbers, Go numeric types and numeric strings to float64
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	default:
+		rv := reflect.ValueOf(value)
+		switch rv.Kind() {
+		case reflect.Float32, reflect.Float64:
+			return rv.Float(), true
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return float64(rv.Int()), true
+		}
+		return 0, false
+	}
+}
+
+func toBool(value interface{}) (bool, bool) {
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case string:
+		b, err := strconv.ParseBool(v)
+		return b, err == nil
+	default:
+		return false, false
+	}
+}
+
+func toTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
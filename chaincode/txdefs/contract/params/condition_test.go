@@ -0,0 +1,103 @@
+package params
+
+import (
+	"testing"
+)
+
+func TestConditionEvaluate(t *testing.T) {
+	data := map[string]interface{}{
+		"amount": 2.9,
+		"count":  float64(3),
+		"status": "paid",
+		"flag":   true,
+		"dueAt":  "2024-03-10T00:00:00Z",
+		"tags":   []interface{}{"a", "b"},
+		"payment": map[string]interface{}{
+			"dateIntervalCheck": map[string]interface{}{
+				"success": true,
+			},
+		},
+		"dotted.key": "whole",
+	}
+
+	tests := []struct {
+		name      string
+		condition Condition
+		want      bool
+		wantErr   bool
+	}{
+		{"equal float", Condition{Path: "amount", ConditionalCheck: Equal, ReferenceValue: 2.9}, true, false},
+		{"int equal", Condition{Path: "count", ConditionalCheck: Equal, ReferenceValue: float64(3), DataType: IntType}, true, false},
+		{"int rejects fractional value", Condition{Path: "amount", ConditionalCheck: Equal, ReferenceValue: float64(2), DataType: IntType}, false, true},
+		{"int rejects fractional reference", Condition{Path: "count", ConditionalCheck: Greater, ReferenceValue: 2.5, DataType: IntType}, false, true},
+		{"not equal string", Condition{Path: "status", ConditionalCheck: NotEqual, ReferenceValue: "late"}, true, false},
+		{"greater", Condition{Path: "count", ConditionalCheck: Greater, ReferenceValue: float64(2)}, true, false},
+		{"smaller or equal", Condition{Path: "count", ConditionalCheck: SmallerOrEqual, ReferenceValue: float64(3)}, true, false},
+		{"between inclusive", Condition{Path: "count", ConditionalCheck: Between, ReferenceValue: []interface{}{float64(1), float64(3)}}, true, false},
+		{"between outside", Condition{Path: "amount", ConditionalCheck: Between, ReferenceValue: []interface{}{float64(3), float64(5)}}, false, false},
+		{"between int boundary", Condition{Path: "amount", ConditionalCheck: Between, ReferenceValue: []interface{}{float64(1), float64(2)}, DataType: IntType}, false, true},
+		{"between malformed", Condition{Path: "count", ConditionalCheck: Between, ReferenceValue: float64(1)}, false, true},
+		{"in", Condition{Path: "status", ConditionalCheck: In, ReferenceValue: []interface{}{"open", "paid"}}, true, false},
+		{"not in", Condition{Path: "status", ConditionalCheck: NotIn, ReferenceValue: []interface{}{"open"}}, true, false},
+		{"contains string", Condition{Path: "status", ConditionalCheck: Contains, ReferenceValue: "ai"}, true, false},
+		{"contains list", Condition{Path: "tags", ConditionalCheck: Contains, ReferenceValue: "b"}, true, false},
+		{"exists nested", Condition{Path: "payment.dateIntervalCheck.success", ConditionalCheck: Exists}, true, false},
+		{"not exists", Condition{Path: "payment.missing", ConditionalCheck: NotExists}, true, false},
+		{"dotted key", Condition{Path: "dotted.key", ConditionalCheck: Equal, ReferenceValue: "whole"}, true, false},
+		{"missing path", Condition{Path: "missing", ConditionalCheck: Equal, ReferenceValue: "x"}, false, false},
+		{"bool equal", Condition{Path: "flag", ConditionalCheck: Equal, ReferenceValue: true}, true, false},
+		{"bool greater unsupported", Condition{Path: "flag", ConditionalCheck: Greater, ReferenceValue: true, DataType: BoolType}, false, true},
+		{"before", Condition{Path: "dueAt", ConditionalCheck: Before, ReferenceValue: "2024-03-11"}, true, false},
+		{"after", Condition{Path: "dueAt", ConditionalCheck: After, ReferenceValue: "2024-03-11"}, false, false},
+		{"unsupported check", Condition{Path: "count", ConditionalCheck: "matches", ReferenceValue: "x"}, false, true},
+		{"empty", Condition{}, false, true},
+		{"and", Condition{And: []Condition{
+			{Path: "status", ConditionalCheck: Equal, ReferenceValue: "paid"},
+			{Path: "flag", ConditionalCheck: Equal, ReferenceValue: false},
+		}}, false, false},
+		{"or", Condition{Or: []Condition{
+			{Path: "status", ConditionalCheck: Equal, ReferenceValue: "late"},
+			{Path: "flag", ConditionalCheck: Equal, ReferenceValue: true},
+		}}, true, false},
+		{"not", Condition{Not: &Condition{Path: "status", ConditionalCheck: Equal, ReferenceValue: "late"}}, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.condition.Evaluate(data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Evaluate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Evaluate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupPath(t *testing.T) {
+	data := map[string]interface{}{
+		"list": []interface{}{map[string]interface{}{"value": "first"}},
+		"nested": map[string]interface{}{
+			"inner": float64(1),
+		},
+	}
+
+	tests := []struct {
+		path   string
+		want   interface{}
+		exists bool
+	}{
+		{"list.0.value", "first", true},
+		{"list.1.value", nil, false},
+		{"nested.inner", float64(1), true},
+		{"nested.missing", nil, false},
+	}
+
+	for _, tt := range tests {
+		got, exists := LookupPath(data, tt.path)
+		if exists != tt.exists || got != tt.want {
+			t.Errorf("LookupPath(%q) = %v, %v, want %v, %v", tt.path, got, exists, tt.want, tt.exists)
+		}
+	}
+}
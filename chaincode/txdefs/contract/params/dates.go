@@ -0,0 +1,98 @@
+package params
+
+import "time"
+
+const (
+	periodicityWeekly    string = "weekly"
+	periodicityBiweekly  string = "biweekly"
+	periodicityMonthly   string = "monthly"
+	periodicityQuarterly string = "quarterly"
+	periodicityYearly    string = "yearly"
+)
+
+// addPeriods returns the date n periods after start.
+// Months are added keeping the day of start, clamped to the last day of shorter months (e.g. Jan 31 -> Feb 28).
+func addPeriods(start time.Time, periodicity string, n int) (time.Time, bool) {
+	switch periodicity {
+	case periodicityWeekly:
+		return start.AddDate(0, 0, 7*n), true
+	case periodicityBiweekly:
+		return start.AddDate(0, 0, 14*n), true
+	case periodicityMonthly, "":
+		return addMonthsClamped(start, n), true
+	case periodicityQuarterly:
+		return addMonthsClamped(start, 3*n), true
+	case periodicityYearly:
+		return addMonthsClamped(start, 12*n), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func addMonthsClamped(t time.Time, months int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
+
+// daysBetween returns the number of whole days from a to b, negative when b is before a
+func daysBetween(a, b time.Time) int {
+	return int(b.Sub(a).Hours() / 24)
+}
+
+// holidays holds the dates, in 2006-01-02 format, and the yearly dates, in 01-02 format, that are not business days
+type holidays struct {
+	dates     map[string]bool
+	recurring map[string]bool
+}
+
+func newHolidays(dates []string, recurring []string) holidays {
+	h := holidays{
+		dates:     make(map[string]bool),
+		recurring: make(map[string]bool),
+	}
+	for _, d := range dates {
+		if t, ok := toTime(d); ok {
+			h.dates[t.UTC().Format("2006-01-02")] = true
+		}
+	}
+	for _, r := range recurring {
+		h.recurring[r] = true
+	}
+	return h
+}
+
+func (h holidays) isBusinessDay(t time.Time) bool {
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return false
+	}
+	return !h.dates[t.Format("2006-01-02")] && !h.recurring[t.Format("01-02")]
+}
+
+// addBusinessDays moves n business days from t, backwards when n is negative.
+// The day of t itself is not counted, as legal deadlines start on the next business day.
+func addBusinessDays(t time.Time, n int, h holidays) time.Time {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for n > 0 {
+		t = t.AddDate(0, 0, step)
+		if h.isBusinessDay(t) {
+			n--
+		}
+	}
+	return t
+}
+
+// startOfDay returns midnight of the day of t in loc
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
@@ -2,7 +2,7 @@ package params
 
 import (
 	"encoding/json"
-	"time"
+	"net/http"
 
 	"github.com/hyperledger-labs/cc-tools/errors"
 	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
@@ -11,6 +11,12 @@ import (
 
 type FinalizeContract struct{}
 
+// Contract status reported on the result meta when the finish contract clause closes the contract
+const (
+	ContractFinalized = "finalized"
+	ContractCancelled = "cancelled"
+)
+
 type DataType string
 
 const (
@@ -24,10 +30,20 @@ const (
 type ConditionalCheck string
 
 const (
-	Equal    ConditionalCheck = "equal"
-	NotEqual ConditionalCheck = "notEqual"
-	Greater  ConditionalCheck = "greater"
-	Smaller  ConditionalCheck = "smaller"
+	Equal          ConditionalCheck = "equal"
+	NotEqual       ConditionalCheck = "notEqual"
+	Greater        ConditionalCheck = "greater"
+	GreaterOrEqual ConditionalCheck = "greaterOrEqual"
+	Smaller        ConditionalCheck = "smaller"
+	SmallerOrEqual ConditionalCheck = "smallerOrEqual"
+	Between        ConditionalCheck = "between"
+	In             ConditionalCheck = "in"
+	NotIn          ConditionalCheck = "notIn"
+	Contains       ConditionalCheck = "contains"
+	Exists         ConditionalCheck = "exists"
+	NotExists      ConditionalCheck = "notExists"
+	Before         ConditionalCheck = "before"
+	After          ConditionalCheck = "after"
 )
 
 type checkValue struct {
@@ -37,11 +53,42 @@ type checkValue struct {
 	ConditionalCheck ConditionalCheck `json:"conditionalCheck"`
 }
 
+// toCondition converts the single value check to a condition on a top-level key of the data
+func (cv checkValue) toCondition() *Condition {
+	if cv.Tag == "" {
+		return nil
+	}
+	return &Condition{
+		Path:             cv.Tag,
+		ConditionalCheck: cv.ConditionalCheck,
+		ReferenceValue:   cv.ReferenceValue,
+		DataType:         cv.DataType,
+	}
+}
+
 type FinalizeContractParams struct {
-	AutoFinalizationValue  checkValue `json:"autoFinalizationValue"`
-	CancellationCheckValue checkValue `json:"cancellationCheckValue"`
-	ForceCancellation      bool       `json:"forceCancellation"`
-	RequestedCancellation  bool       `json:"requestedCancellation"`
+	AutoFinalizationValue     checkValue `json:"autoFinalizationValue"`
+	CancellationCheckValue    checkValue `json:"cancellationCheckValue"`
+	AutoFinalizationCondition *Condition `json:"autoFinalizationCondition,omitempty"`
+	CancellationCondition     *Condition `json:"cancellationCondition,omitempty"`
+	ForceCancellation         bool       `json:"forceCancellation"`
+	RequestedCancellation     bool       `json:"requestedCancellation"`
+}
+
+// autoFinalizationRule returns the auto finalization condition, falling back to the legacy single value check
+func (p FinalizeContractParams) autoFinalizationRule() *Condition {
+	if p.AutoFinalizationCondition != nil {
+		return p.AutoFinalizationCondition
+	}
+	return p.AutoFinalizationValue.toCondition()
+}
+
+// cancellationRule returns the cancellation condition, falling back to the legacy single value check
+func (p FinalizeContractParams) cancellationRule() *Condition {
+	if p.CancellationCondition != nil {
+		return p.CancellationCondition
+	}
+	return p.CancellationCheckValue.toCondition()
 }
 
 func (a *FinalizeContract) Type() datatypes.ActionType {
@@ -73,30 +120,37 @@ func (a *FinalizeContract) Execute(input interface{}, data map[string]interface{
 		return &models.Result{
 			Success:  true,
 			Feedback: "Contract cancelled upon force cancellation",
+			Meta:     map[string]interface{}{"contractStatus": ContractCancelled},
 		}, true, nil
 	}
 
-	if (params.CancellationCheckValue != checkValue{}) {
-		if params.RequestedCancellation {
-			if err := a.evaluateCheckValue(params.CancellationCheckValue, data); err == nil {
-				return &models.Result{
-					Success:  true,
-					Feedback: "Contract cancelled upon request based on defined conditions",
-				}, true, nil
-			} else {
-				return nil, false, errors.WrapError(err, "Contract cancellation condition not met")
-			}
+	if cancellation := params.cancellationRule(); cancellation != nil && params.RequestedCancellation {
+		met, err := cancellation.Evaluate(data)
+		if err != nil {
+			return nil, false, errors.WrapError(err, "Failed to evaluate cancellation condition")
 		}
+		if !met {
+			return nil, false, errors.NewCCError("Contract cancellation condition not met", http.StatusBadRequest)
+		}
+
+		return &models.Result{
+			Success:  true,
+			Feedback: "Contract cancelled upon request based on defined conditions",
+			Meta:     map[string]interface{}{"contractStatus": ContractCancelled},
+		}, true, nil
 	}
 
-	if (params.AutoFinalizationValue != checkValue{}) {
-		if err := a.evaluateCheckValue(params.AutoFinalizationValue, data); err == nil {
+	if finalization := params.autoFinalizationRule(); finalization != nil {
+		met, err := finalization.Evaluate(data)
+		if err != nil {
+			return nil, false, errors.WrapError(err, "Failed to evaluate finalization condition")
+		}
+		if met {
 			return &models.Result{
 				Success:  true,
 				Feedback: "Contract automatically finalized based on defined conditions.",
+				Meta:     map[string]interface{}{"contractStatus": ContractFinalized},
 			}, true, nil
-		} else {
-			return nil, false, errors.WrapError(err, "Contract finalization condition not met")
 		}
 	}
 
@@ -105,176 +159,3 @@ func (a *FinalizeContract) Execute(input interface{}, data map[string]interface{
 		Feedback: "Contract remains active; no conditions for finalization were met.",
 	}, false, nil
 }
-
-func (a *FinalizeContract) evaluateCheckValue(cv checkValue, data map[string]interface{}) errors.ICCError {
-	value, exists := data[cv.Tag]
-	if !exists {
-		return errors.NewCCError("Value not found in contract data", 400)
-	}
-
-	switch cv.DataType {
-	case IntType:
-		return a.evaluateInt(cv, value)
-	case FloatType:
-		return a.evaluateFloat(cv, value)
-	case BoolType:
-		return a.evaluateBool(cv, value)
-	case DateType:
-		return a.evaluateDate(cv, value)
-	case StringType:
-		return a.evaluateString(cv, value)
-	default:
-		return errors.NewCCError("Unsupported data type", 400)
-	}
-}
-
-func (a *FinalizeContract) evaluateInt(cv checkValue, value interface{}) errors.ICCError {
-	intVal, ok := value.(int)
-	if !ok {
-		return errors.NewCCError("Value must be of type int", 400)
-	}
-
-	val, ok := cv.ReferenceValue.(int)
-	if !ok {
-		return errors.NewCCError("Reference value must be of int type", 400)
-	}
-
-	switch cv.ConditionalCheck {
-	case Equal:
-		if intVal == val {
-			return nil
-		}
-	case NotEqual:
-		if intVal != val {
-			return nil
-		}
-	case Greater:
-		if intVal > val {
-			return nil
-		}
-	case Smaller:
-		if intVal < val {
-			return nil
-		}
-	}
-
-	return errors.NewCCError("Condition not met for int evaluation", 400)
-}
-
-func (a *FinalizeContract) evaluateFloat(cv checkValue, value interface{}) errors.ICCError {
-	floatVal, ok := value.(float64)
-	if !ok {
-		return errors.NewCCError("Invalid float value", 400)
-	}
-
-	val, ok := cv.ReferenceValue.(float64)
-	if !ok {
-		return errors.NewCCError("Reference value must be of type float", 400)
-	}
-
-	switch cv.ConditionalCheck {
-	case Equal:
-		if floatVal == val {
-			return nil
-		}
-	case NotEqual:
-		if floatVal != val {
-			return nil
-		}
-	case Greater:
-		if floatVal > val {
-			return nil
-		}
-	case Smaller:
-		if floatVal < val {
-			return nil
-		}
-	}
-
-	return errors.NewCCError("Condition not met for float evaluation", 400)
-}
-
-func (a *FinalizeContract) evaluateBool(cv checkValue, value interface{}) errors.ICCError {
-	boolVal, ok := value.(bool)
-	if !ok {
-		return errors.NewCCError("Invalid bool value", 400)
-	}
-
-	expectedVal, ok := cv.ReferenceValue.(bool)
-	if !ok {
-		return errors.NewCCError("Reference value must be of type boolean", 400)
-	}
-
-	if cv.ConditionalCheck == Equal && boolVal == expectedVal {
-		return nil
-	}
-
-	if cv.ConditionalCheck == NotEqual && boolVal != expectedVal {
-		return nil
-	}
-
-	return errors.NewCCError("Condition not met for bool evaluation", 400)
-}
-
-func (a *FinalizeContract) evaluateDate(cv checkValue, value interface{}) errors.ICCError {
-	dateVal, err := time.Parse(time.RFC3339, value.(string))
-	if err != nil {
-		return errors.WrapError(err, "Invalid date value")
-	}
-
-	refDateStr, ok := cv.ReferenceValue.(string)
-	if !ok {
-		return errors.NewCCError("Reference value must be of type string", 400)
-	}
-
-	expectedDate, err := time.Parse(time.RFC3339, refDateStr)
-	if err != nil {
-		return errors.WrapError(err, "Invalid condition for date")
-	}
-
-	switch cv.ConditionalCheck {
-	case Equal:
-		if dateVal.Equal(expectedDate) {
-			return nil
-		}
-	case NotEqual:
-		if !dateVal.Equal(expectedDate) {
-			return nil
-		}
-	case Greater:
-		if dateVal.After(expectedDate) {
-			return nil
-		}
-	case Smaller:
-		if dateVal.Before(expectedDate) {
-			return nil
-		}
-	}
-
-	return errors.NewCCError("Condition not met for date evaluation", 400)
-}
-
-func (a *FinalizeContract) evaluateString(cv checkValue, value interface{}) errors.ICCError {
-	strVal, ok := value.(string)
-	if !ok {
-		return errors.NewCCError("Value must be of type string", 400)
-	}
-
-	refVal, ok := cv.ReferenceValue.(string)
-	if !ok {
-		return errors.NewCCError("Reference value must be of type string", 400)
-	}
-
-	switch cv.ConditionalCheck {
-	case Equal:
-		if strVal == refVal {
-			return nil
-		}
-	case NotEqual:
-		if strVal != refVal {
-			return nil
-		}
-	}
-
-	return errors.NewCCError("Condition not met for string evaluation", 400)
-}
@@ -0,0 +1,228 @@
+package params
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/txdefs/contract/models"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+const (
+	indexAdjustmentName = "indexAdjustment"
+	indexRatesInput     = "indexRates"
+)
+
+// AdjustByIndex corrects a reference amount by the accumulated rate of an index
+// (e.g. IPCA, IGP-M, SELIC, CDI) over the months from StartDate up to, and not including, EndDate.
+type AdjustByIndex struct{}
+
+type AdjustByIndexParams struct {
+	Name                string  `json:"name"`
+	Index               string  `json:"index"`
+	ReferenceAmount     float64 `json:"referenceAmount"`
+	ReferenceAdjustment string  `json:"referenceAdjustment"` // name of a previous adjustment whose adjusted amount is used as the reference amount
+	IgnoreDeflation     bool    `json:"ignoreDeflation"`     // a negative accumulated rate keeps the reference amount
+}
+
+type AdjustByIndexInputs struct {
+	StartDate  string           `json:"startDate"`
+	EndDate    string           `json:"endDate"`
+	IndexRates []IndexRateValue `json:"indexRates"` // loaded from the ledger before execution
+}
+
+type IndexRateValue struct {
+	Date  string  `json:"date"`
+	Value float64 `json:"value"`
+}
+
+func (a *AdjustByIndex) Type() datatypes.ActionType {
+	return datatypes.IndexAdjustment
+}
+
+func (a *AdjustByIndex) GetParameters() interface{} {
+	return AdjustByIndexParams{}
+}
+
+func (a *AdjustByIndex) GetInputs() interface{} {
+	return AdjustByIndexInputs{}
+}
+
+// LoadInputs reads the published rates of the index for the adjustment period
+func (a *AdjustByIndex) LoadInputs(stub *sw.StubWrapper, inputs map[string]interface{}) errors.ICCError {
+	params, period, err := a.decode(inputs)
+	if err != nil {
+		return err
+	}
+
+	query := map[string]interface{}{
+		"selector": map[string]interface{}{
+			"@assetType": "indexRate",
+			"index":      params.Index,
+			"date": map[string]interface{}{
+				"$gte": period.StartDate,
+				"$lt":  period.EndDate,
+			},
+		},
+	}
+
+	response, err := assets.Search(stub, query, "", false)
+	if err != nil {
+		return errors.WrapErrorWithStatus(err, "error searching for index rates", http.StatusInternalServerError)
+	}
+
+	rates := make([]IndexRateValue, 0, len(response.Result))
+	for _, r := range response.Result {
+		date, _ := r["date"].(string)
+		value, _ := r["value"].(float64)
+		rates = append(rates, IndexRateValue{Date: date, Value: value})
+	}
+
+	inputs[indexRatesInput] = rates
+	return nil
+}
+
+func (a *AdjustByIndex) Execute(input interface{}, data map[string]interface{}) (*models.Result, bool, errors.ICCError) {
+	inputBytes, nerr := json.Marshal(input)
+	if nerr != nil {
+		return nil, false, errors.WrapError(nerr, "Failed to marshal input")
+	}
+
+	var inputMap map[string]interface{}
+	nerr = json.Unmarshal(inputBytes, &inputMap)
+	if nerr != nil {
+		return nil, false, errors.WrapError(nerr, "Failed to unmarshal input")
+	}
+
+	params, period, err := a.decode(inputMap)
+	if err != nil {
+		return nil, false, err
+	}
+
+	referenceAmount := params.ReferenceAmount
+	if params.ReferenceAdjustment != "" {
+		adjusted, ok := adjustedAmount(data, params.ReferenceAdjustment)
+		if !ok {
+			return &models.Result{
+				Success:  false,
+				Feedback: fmt.Sprintf("Waiting for adjustment '%s' to be executed", params.ReferenceAdjustment),
+			}, false, nil
+		}
+		referenceAmount = adjusted
+	}
+
+	start, _ := time.Parse(time.RFC3339, period.StartDate)
+	end, _ := time.Parse(time.RFC3339, period.EndDate)
+	months := (end.Year()-start.Year())*12 + int(end.Month()-start.Month())
+
+	ratesByMonth := make(map[string]float64)
+	for _, rate := range period.IndexRates {
+		month, err := utils.ParseMonth(rate.Date)
+		if err != nil || month.Before(start) || !month.Before(end) {
+			continue
+		}
+		ratesByMonth[month.Format(time.RFC3339)] = rate.Value
+	}
+
+	if len(ratesByMonth) < months {
+		return &models.Result{
+			Success:  false,
+			Feedback: fmt.Sprintf("Waiting for %s rates: %d of %d months published", params.Index, len(ratesByMonth), months),
+		}, false, nil
+	}
+
+	factor := 1.0
+	for _, value := range ratesByMonth {
+		factor *= 1 + value/100
+	}
+
+	appliedFactor := factor
+	if params.IgnoreDeflation && appliedFactor < 1 {
+		appliedFactor = 1
+	}
+
+	adjusted := roundCents(referenceAmount * appliedFactor)
+	accumulatedRate := (factor - 1) * 100
+
+	if params.Name == "" {
+		params.Name = indexAdjustmentName
+	}
+
+	data[params.Name] = map[string]interface{}{
+		"index":           params.Index,
+		"startDate":       period.StartDate,
+		"endDate":         period.EndDate,
+		"months":          months,
+		"referenceAmount": referenceAmount,
+		"accumulatedRate": accumulatedRate,
+		"factor":          appliedFactor,
+		"adjustedAmount":  adjusted,
+	}
+
+	return &models.Result{
+		Success:  true,
+		Feedback: fmt.Sprintf("Amount adjusted by %.4f%% of %s accumulated in %d months", accumulatedRate, params.Index, months),
+		Data:     data,
+		Meta: map[string]interface{}{
+			"referenceAmount": referenceAmount,
+			"adjustedAmount":  adjusted,
+		},
+	}, true, nil
+}
+
+// decode reads the parameters and inputs, normalizing the index name and the period to whole months
+func (a *AdjustByIndex) decode(inputs map[string]interface{}) (AdjustByIndexParams, AdjustByIndexInputs, errors.ICCError) {
+	var params AdjustByIndexParams
+	var period AdjustByIndexInputs
+
+	inputBytes, nerr := json.Marshal(inputs)
+	if nerr != nil {
+		return params, period, errors.WrapError(nerr, "Failed to marshal input")
+	}
+	nerr = json.Unmarshal(inputBytes, &params)
+	if nerr != nil {
+		return params, period, errors.WrapError(nerr, "Failed to unmarshal AdjustByIndexParams")
+	}
+	nerr = json.Unmarshal(inputBytes, &period)
+	if nerr != nil {
+		return params, period, errors.WrapError(nerr, "Failed to unmarshal AdjustByIndexInputs")
+	}
+
+	params.Index = strings.ToUpper(strings.TrimSpace(params.Index))
+	if params.Index == "" {
+		return params, period, errors.NewCCError("Index must be provided", http.StatusBadRequest)
+	}
+
+	start, nerr := utils.ParseMonth(period.StartDate)
+	if nerr != nil {
+		return params, period, errors.WrapErrorWithStatus(nerr, "Invalid start date", http.StatusBadRequest)
+	}
+	end, nerr := utils.ParseMonth(period.EndDate)
+	if nerr != nil {
+		return params, period, errors.WrapErrorWithStatus(nerr, "Invalid end date", http.StatusBadRequest)
+	}
+	if !end.After(start) {
+		return params, period, errors.NewCCError("End date must be in a month after the start date", http.StatusBadRequest)
+	}
+
+	period.StartDate = start.Format(time.RFC3339)
+	period.EndDate = end.Format(time.RFC3339)
+
+	return params, period, nil
+}
+
+// adjustedAmount returns the adjusted amount written to the contract data by an index adjustment
+func adjustedAmount(data map[string]interface{}, name string) (float64, bool) {
+	value, exists := LookupPath(data, name+".adjustedAmount")
+	if !exists {
+		return 0, false
+	}
+	return toFloat(value)
+}
@@ -0,0 +1,285 @@
+package params
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/txdefs/contract/models"
+)
+
+const (
+	installmentsName = "installments"
+
+	installmentOpen = "open"
+	installmentPaid = "paid"
+	installmentLate = "late"
+)
+
+// InstallmentSchedule splits a total amount into installments due periodically.
+// Every execution rebuilds the schedule from the parameters and the payments received so far,
+// so late installments are charged a fine only for the days not yet charged.
+type InstallmentSchedule struct{}
+
+type InstallmentsParams struct {
+	Name                string  `json:"name"`
+	Total               float64 `json:"total"`
+	AmountFrom          string  `json:"amountFrom"` // name of an index adjustment whose adjusted amount replaces total
+	Count               int     `json:"count"`
+	FirstDueDate        string  `json:"firstDueDate"`
+	Periodicity         string  `json:"periodicity"` // weekly, biweekly, monthly (default), quarterly or yearly
+	FineDailyPercentage float64 `json:"fineDailyPercentage"`
+	FineMaxPercentage   float64 `json:"fineMaxPercentage"` // limits the daily fine to this percentage of the installment amount
+}
+
+type InstallmentsInputs struct {
+	Payments      []InstallmentPayment `json:"payments"`
+	ExecutionDate string               `json:"executionDate"`
+}
+
+type InstallmentPayment struct {
+	Installment int     `json:"installment,omitempty"` // applied to the oldest open installments when not set
+	Date        string  `json:"date"`
+	Amount      float64 `json:"amount"`
+	ReceiptHash string  `json:"receiptHash,omitempty"`
+	DetailsHash string  `json:"detailsHash,omitempty"` // salted hash of the receipt URL, kept on the paymentDetails collection
+}
+
+type Installment struct {
+	Number     int                  `json:"number"`
+	DueDate    string               `json:"dueDate"`
+	Amount     float64              `json:"amount"`
+	PaidAmount float64              `json:"paidAmount"`
+	PaidDate   string               `json:"paidDate,omitempty"`
+	Receipts   []InstallmentPayment `json:"receipts,omitempty"`
+	Status     string               `json:"status"`
+	DaysLate   int                  `json:"daysLate"`
+	Fine       float64              `json:"fine"`
+}
+
+func (a *InstallmentSchedule) Type() datatypes.ActionType {
+	return datatypes.Installments
+}
+
+func (a *InstallmentSchedule) GetParameters() interface{} {
+	return InstallmentsParams{}
+}
+
+func (a *InstallmentSchedule) GetInputs() interface{} {
+	return InstallmentsInputs{}
+}
+
+func (a *InstallmentSchedule) Execute(input interface{}, data map[string]interface{}) (*models.Result, bool, errors.ICCError) {
+	inputBytes, err := json.Marshal(input)
+	if err != nil {
+		return nil, false, errors.WrapError(err, "Failed to marshal input")
+	}
+
+	var params InstallmentsParams
+	err = json.Unmarshal(inputBytes, &params)
+	if err != nil {
+		return nil, false, errors.WrapError(err, "Failed to unmarshal InstallmentsParams")
+	}
+
+	var inputs InstallmentsInputs
+	err = json.Unmarshal(inputBytes, &inputs)
+	if err != nil {
+		return nil, false, errors.WrapError(err, "Failed to unmarshal InstallmentsInputs")
+	}
+
+	if params.Name == "" {
+		params.Name = installmentsName
+	}
+
+	if params.AmountFrom != "" {
+		adjusted, ok := adjustedAmount(data, params.AmountFrom)
+		if !ok {
+			return &models.Result{
+				Success:  false,
+				Feedback: fmt.Sprintf("Waiting for adjusted amount '%s'", params.AmountFrom),
+			}, false, nil
+		}
+		params.Total = adjusted
+	}
+
+	installments, cerr := a.buildSchedule(params)
+	if cerr != nil {
+		return nil, false, cerr
+	}
+
+	cerr = a.applyPayments(installments, inputs.Payments)
+	if cerr != nil {
+		return nil, false, cerr
+	}
+
+	executionDate, ok := toTime(inputs.ExecutionDate)
+	if !ok {
+		return nil, false, errors.NewCCError("Execution date is not available", http.StatusBadRequest)
+	}
+
+	var paidCount, lateCount int
+	var paidTotal, fineTotal float64
+	for i := range installments {
+		inst := &installments[i]
+		dueDate, _ := toTime(inst.DueDate)
+
+		// Late days count up to the payment date, or up to now while the installment is open
+		lateUntil := executionDate
+		if inst.PaidDate != "" {
+			inst.Status = installmentPaid
+			paidCount++
+			lateUntil, _ = toTime(inst.PaidDate)
+		} else if executionDate.After(dueDate) {
+			inst.Status = installmentLate
+			lateCount++
+		} else {
+			inst.Status = installmentOpen
+		}
+
+		if days := daysBetween(dueDate, lateUntil); days > 0 {
+			inst.DaysLate = days
+			inst.Fine = roundCents(computeFine(inst.Amount, params.FineDailyPercentage, float64(days), params.FineMaxPercentage, inst.Amount))
+		}
+
+		paidTotal += inst.PaidAmount
+		fineTotal += inst.Fine
+
+		// Only the fine not charged on previous executions is added to the contract fines
+		chargedFine, _ := LookupPath(data, fmt.Sprintf("%s.installments.%d.fine", params.Name, i))
+		charged, _ := toFloat(chargedFine)
+		if delta := inst.Fine - charged; delta > 0.005 {
+			addFine(data, fmt.Sprintf("%s_%d", params.Name, inst.Number), delta, fmt.Sprintf("Installment %d is %d days late.", inst.Number, inst.DaysLate))
+		}
+	}
+
+	var schedule []interface{}
+	scheduleBytes, err := json.Marshal(installments)
+	if err != nil {
+		return nil, false, errors.WrapError(err, "Failed to marshal installments")
+	}
+	err = json.Unmarshal(scheduleBytes, &schedule)
+	if err != nil {
+		return nil, false, errors.WrapError(err, "Failed to unmarshal installments")
+	}
+
+	data[params.Name] = map[string]interface{}{
+		"total":        params.Total,
+		"count":        params.Count,
+		"periodicity":  params.Periodicity,
+		"installments": schedule,
+		"paidAmount":   roundCents(paidTotal),
+		"remaining":    roundCents(math.Max(params.Total-paidTotal, 0)),
+		"fine":         roundCents(fineTotal),
+		"paidCount":    paidCount,
+		"lateCount":    lateCount,
+	}
+
+	allPaid := paidCount == len(installments)
+	feedback := fmt.Sprintf("%d of %d installments paid, %d late.", paidCount, len(installments), lateCount)
+	if allPaid {
+		feedback = "All installments paid."
+	}
+
+	return &models.Result{
+		Success:  allPaid,
+		Feedback: feedback,
+		Data:     data,
+		Meta: map[string]interface{}{
+			"paidAmount": roundCents(paidTotal),
+			"fine":       roundCents(fineTotal),
+		},
+	}, allPaid, nil
+}
+
+// buildSchedule splits the total in equal installments, the last one absorbing the rounding difference
+func (a *InstallmentSchedule) buildSchedule(params InstallmentsParams) ([]Installment, errors.ICCError) {
+	if params.Total <= 0 {
+		return nil, errors.NewCCError("Installments total must be greater than zero", http.StatusBadRequest)
+	}
+	if params.Count <= 0 {
+		return nil, errors.NewCCError("Installments count must be greater than zero", http.StatusBadRequest)
+	}
+
+	firstDueDate, ok := toTime(params.FirstDueDate)
+	if !ok {
+		return nil, errors.NewCCError("Invalid first due date", http.StatusBadRequest)
+	}
+
+	amount := roundCents(params.Total / float64(params.Count))
+
+	installments := make([]Installment, params.Count)
+	for i := range installments {
+		dueDate, ok := addPeriods(firstDueDate, params.Periodicity, i)
+		if !ok {
+			return nil, errors.NewCCError(fmt.Sprintf("Invalid periodicity '%s'", params.Periodicity), http.StatusBadRequest)
+		}
+
+		installments[i] = Installment{
+			Number:  i + 1,
+			DueDate: dueDate.Format(time.RFC3339),
+			Amount:  amount,
+		}
+	}
+	installments[params.Count-1].Amount = roundCents(params.Total - amount*float64(params.Count-1))
+
+	return installments, nil
+}
+
+// applyPayments allocates the payments in date order. A payment without an installment number,
+// or one exceeding its installment, is applied to the oldest installments still open.
+func (a *InstallmentSchedule) applyPayments(installments []Installment, payments []InstallmentPayment) errors.ICCError {
+	sorted := make([]InstallmentPayment, len(payments))
+	copy(sorted, payments)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		dateI, _ := toTime(sorted[i].Date)
+		dateJ, _ := toTime(sorted[j].Date)
+		return dateI.Before(dateJ)
+	})
+
+	for _, payment := range sorted {
+		if _, ok := toTime(payment.Date); !ok {
+			return errors.NewCCError(fmt.Sprintf("Invalid payment date '%s'", payment.Date), http.StatusBadRequest)
+		}
+		if payment.Installment < 0 || payment.Installment > len(installments) {
+			return errors.NewCCError(fmt.Sprintf("Installment %d does not exist", payment.Installment), http.StatusBadRequest)
+		}
+
+		remaining := payment.Amount
+		apply := func(inst *Installment) {
+			due := roundCents(inst.Amount - inst.PaidAmount)
+			if due <= 0 || remaining <= 0 {
+				return
+			}
+			value := math.Min(due, remaining)
+			inst.PaidAmount = roundCents(inst.PaidAmount + value)
+			remaining = roundCents(remaining - value)
+			inst.Receipts = append(inst.Receipts, InstallmentPayment{
+				Date:        payment.Date,
+				Amount:      value,
+				ReceiptHash: payment.ReceiptHash,
+				DetailsHash: payment.DetailsHash,
+			})
+			if inst.PaidAmount >= inst.Amount {
+				inst.PaidDate = payment.Date
+			}
+		}
+
+		if payment.Installment > 0 {
+			apply(&installments[payment.Installment-1])
+		}
+		for i := range installments {
+			apply(&installments[i])
+		}
+	}
+
+	return nil
+}
+
+func roundCents(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -21,6 +21,7 @@ type MakePaymentClause struct{}
 type MakePaymentParams struct {
 	Name           string  `json:"name"`
 	Amount         float64 `json:"amount"`
+	AmountFrom     string  `json:"amountFrom"` // name of an index adjustment whose adjusted amount replaces amount
 	PaymentRate    float64 `json:"paymentRate"`
 	PartialPayment bool    `json:"partialPayment"`
 	AddBonus       bool    `json:"addBonus"`
@@ -28,13 +29,11 @@ type MakePaymentParams struct {
 }
 
 type MakePaymentInputs struct {
-	Date                time.Time `json:"date"`
-	Payment             float64   `json:"payment"`
-	ReceiptHash         string    `json:"receiptHash"`
-	ReceiptUrl          string    `json:"receiptUrl"`
-	FinalPayment        bool      `json:"finalPayment"`
-	StripeToken         string    `json:"stripeToken"`
-	PayPalTransactionID string    `json:"payPalTransactionID"`
+	Date         time.Time `json:"date"`
+	Payment      float64   `json:"payment"`
+	ReceiptHash  string    `json:"receiptHash"`
+	FinalPayment bool      `json:"finalPayment"`
+	DetailsHash  string    `json:"detailsHash"` // salted hash of the receipt URL and payment provider identifiers, kept on the paymentDetails collection
 }
 
 func (a *MakePaymentClause) Type() datatypes.ActionType {
@@ -67,6 +66,17 @@ func (a *MakePaymentClause) Execute(input interface{}, data map[string]interface
 		return nil, false, errors.WrapError(err, "Failed to unmarshal MakePaymentParams")
 	}
 
+	if params.AmountFrom != "" {
+		adjusted, ok := adjustedAmount(data, params.AmountFrom)
+		if !ok {
+			return &models.Result{
+				Success:  false,
+				Feedback: fmt.Sprintf("Waiting for adjusted amount '%s'", params.AmountFrom),
+			}, false, nil
+		}
+		params.Amount = adjusted
+	}
+
 	// Calculate total amount including bonuses and fines
 	totalAmount, bonusPayment, finePayment, err := a.calculateTotalAmount(params, data)
 	if err != nil {
@@ -127,15 +137,8 @@ func (a *MakePaymentClause) updatePaymentData(data map[string]interface{}, param
 	if inputs.ReceiptHash != "" {
 		paymentData["receiptHash"] = inputs.ReceiptHash
 	}
-	if inputs.ReceiptUrl != "" {
-		paymentData["receiptUrl"] = inputs.ReceiptUrl
-	}
-
-	if inputs.StripeToken != "" {
-		paymentData["stripeToken"] = inputs.StripeToken
-	}
-	if inputs.PayPalTransactionID != "" {
-		paymentData["payPalTransactionID"] = inputs.PayPalTransactionID
+	if inputs.DetailsHash != "" {
+		paymentData["detailsHash"] = inputs.DetailsHash
 	}
 
 	data[params.Name] = paymentData
@@ -151,16 +154,8 @@ func (a *MakePaymentClause) createResult(data map[string]interface{}, params Mak
 		"payment":    inputs.Payment,
 	}
 
-	if inputs.ReceiptUrl != "" {
-		assetData["receiptUrl"] = inputs.ReceiptUrl
-	}
-
-	if inputs.StripeToken != "" {
-		assetData["stripeToken"] = inputs.StripeToken
-	}
-
-	if inputs.PayPalTransactionID != "" {
-		assetData["payPalTransactionID"] = inputs.PayPalTransactionID
+	if inputs.DetailsHash != "" {
+		assetData["detailsHash"] = inputs.DetailsHash
 	}
 
 	result := models.Result{
@@ -176,30 +171,74 @@ func (a *MakePaymentClause) createResult(data map[string]interface{}, params Mak
 	}
 
 	if params.AddBonus {
-		result.Data["bonusPaid"] = a.getAmountFromData(data, "bonusPaid") + bonusPayment
+		bonusPaid := a.getAmountFromData(data, "bonusPaid") + bonusPayment
+		result.Data["bonusPaid"] = bonusPaid
+		if credits := unsettledAssets(data, "credit"); len(credits) > 0 && bonusPayment > 0 {
+			assetData["credits"] = credits
+			if bonusPaid >= a.getAmountFromData(data, "bonus") {
+				settleAssets(data, credits)
+			}
+		}
 	}
 	if params.AddFine {
-		result.Data["finePaid"] = a.getAmountFromData(data, "finePaid") + finePayment
+		finePaid := a.getAmountFromData(data, "finePaid") + finePayment
+		result.Data["finePaid"] = finePaid
+		if deductions := unsettledAssets(data, "deduction"); len(deductions) > 0 && finePayment > 0 {
+			assetData["deductions"] = deductions
+			if finePaid >= a.getAmountFromData(data, "fine") {
+				settleAssets(data, deductions)
+			}
+		}
 	}
 
 	return result
 }
 
+// unsettledAssets returns the references of the generated assets of the given type that no payment settled yet
+func unsettledAssets(data map[string]interface{}, assetType string) []interface{} {
+	settled := make(map[string]bool)
+	settledRefs, _ := data["settledAssets"].([]interface{})
+	for _, r := range settledRefs {
+		if ref, ok := r.(map[string]interface{}); ok {
+			if key, ok := ref["@key"].(string); ok {
+				settled[key] = true
+			}
+		}
+	}
+
+	var unsettled []interface{}
+	generated, _ := data["generatedAssets"].([]interface{})
+	for _, r := range generated {
+		ref, ok := r.(map[string]interface{})
+		if !ok || ref["@assetType"] != assetType {
+			continue
+		}
+		if key, ok := ref["@key"].(string); ok && !settled[key] {
+			unsettled = append(unsettled, ref)
+		}
+	}
+
+	return unsettled
+}
+
+// settleAssets adds the references to the "settledAssets" list of the contract data
+func settleAssets(data map[string]interface{}, refs []interface{}) {
+	settled, _ := data["settledAssets"].([]interface{})
+	data["settledAssets"] = append(settled, refs...)
+}
+
 func generatePaymentID(params MakePaymentParams, inputs MakePaymentInputs) string {
 	if inputs.ReceiptHash != "" {
 		return inputs.ReceiptHash
 	}
 
-	var uniqueData string
-	if inputs.StripeToken != "" {
-		uniqueData = inputs.StripeToken
-	} else if inputs.PayPalTransactionID != "" {
-		uniqueData = inputs.PayPalTransactionID
-	} else {
-		// Fallback to creating a hash based on the payment name and amount
-		uniqueData = fmt.Sprintf("%s-%f", params.Name, inputs.Payment)
+	if inputs.DetailsHash != "" {
+		return inputs.DetailsHash
 	}
 
+	// Fallback to creating a hash based on the payment name and amount
+	uniqueData := fmt.Sprintf("%s-%f", params.Name, inputs.Payment)
+
 	hash := sha256.Sum256([]byte(uniqueData))
 	return hex.EncodeToString(hash[:])
 }
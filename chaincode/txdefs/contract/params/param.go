@@ -2,6 +2,7 @@ package params
 
 import (
 	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
 	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
 	"github.com/hyperledger-labs/clausia-cc/chaincode/txdefs/contract/models"
 )
@@ -16,6 +17,12 @@ type param interface {
 	GetInputs() interface{}
 }
 
+// LedgerLoader is implemented by actions that need ledger data besides the contract data.
+// LoadInputs is called before Execute and adds that data to the inputs.
+type LedgerLoader interface {
+	LoadInputs(stub *sw.StubWrapper, inputs map[string]interface{}) errors.ICCError
+}
+
 type Output struct {
 	Name  string
 	Type  string
@@ -34,6 +41,10 @@ func Get(actionType datatypes.ActionType) param {
 		return &MakePaymentClause{}
 	case datatypes.FinishContract:
 		return &FinalizeContract{}
+	case datatypes.IndexAdjustment:
+		return &AdjustByIndex{}
+	case datatypes.Installments:
+		return &InstallmentSchedule{}
 	default:
 		return nil // to be changed according to non executable action type
 	}
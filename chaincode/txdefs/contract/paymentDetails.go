@@ -0,0 +1,136 @@
+package contract
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// paymentDetailsFields are the payment inputs kept on the private paymentDetails collection
+var paymentDetailsFields = []string{"receiptUrl", "stripeToken", "payPalTransactionID"}
+
+// paymentDetailsArgs are the private arguments carrying the payment details, read from the transient map
+var paymentDetailsArgs = []tx.Argument{
+	{
+		Tag:      "receiptUrl",
+		Label:    "Receipt URL",
+		DataType: "string",
+		Private:  true,
+	},
+	{
+		Tag:      "stripeToken",
+		Label:    "Stripe Token",
+		DataType: "string",
+		Private:  true,
+	},
+	{
+		Tag:      "payPalTransactionID",
+		Label:    "PayPal Transaction ID",
+		DataType: "string",
+		Private:  true,
+	},
+	{
+		Tag:         "salt",
+		Label:       "Salt",
+		DataType:    "string",
+		Private:     true,
+		Description: "Random value hashed with the payment details. Required with any of them",
+	},
+}
+
+// paymentDetails returns the payment details of the request, nil when there are none
+func paymentDetails(req map[string]interface{}) map[string]interface{} {
+	details := make(map[string]interface{})
+	for _, field := range paymentDetailsFields {
+		if value, ok := req[field].(string); ok && value != "" {
+			details[field] = value
+		}
+	}
+	if len(details) == 0 {
+		return nil
+	}
+
+	return details
+}
+
+// removePaymentDetails deletes the payment details found anywhere in the value
+func removePaymentDetails(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, field := range paymentDetailsFields {
+			delete(v, field)
+		}
+		for _, nested := range v {
+			removePaymentDetails(nested)
+		}
+	case []interface{}:
+		for _, nested := range v {
+			removePaymentDetails(nested)
+		}
+	}
+}
+
+// paymentDetailsHash hashes the salt with the JSON encoding of the details, which has sorted keys
+func paymentDetailsHash(salt string, details map[string]interface{}) (string, errors.ICCError) {
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return "", errors.WrapErrorWithStatus(err, "Failed to marshal payment details", http.StatusInternalServerError)
+	}
+
+	hash := sha256.Sum256(append([]byte(salt), detailsJSON...))
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// putPaymentDetails stores the payment details of the request on the private collection and
+// returns their salted hash, or an empty string when the request has no payment details
+func putPaymentDetails(stub *sw.StubWrapper, contract assets.Asset, clauseKey assets.Key, req map[string]interface{}) (string, errors.ICCError) {
+	details := paymentDetails(req)
+	if details == nil {
+		return "", nil
+	}
+
+	salt, _ := req["salt"].(string)
+	if salt == "" {
+		return "", errors.NewCCError("A salt is required to store the payment details", http.StatusBadRequest)
+	}
+
+	hash, err := paymentDetailsHash(salt, details)
+	if err != nil {
+		return "", err
+	}
+
+	detailsMap := map[string]interface{}{
+		"@assetType": "paymentDetails",
+		"hash":       hash,
+		"autoExecutableContract": map[string]interface{}{
+			"@assetType": "autoExecutableContract",
+			"@key":       contract.Key(),
+		},
+		"clause": map[string]interface{}{
+			"@assetType": "clause",
+			"@key":       clauseKey.Key(),
+		},
+		"salt": salt,
+	}
+	for field, value := range details {
+		detailsMap[field] = value
+	}
+
+	detailsAsset, err := assets.NewAsset(detailsMap)
+	if err != nil {
+		return "", errors.WrapError(err, "Failed to create payment details asset")
+	}
+
+	_, err = detailsAsset.Put(stub)
+	if err != nil {
+		return "", errors.WrapError(err, "Failed to save payment details")
+	}
+
+	return hash, nil
+}
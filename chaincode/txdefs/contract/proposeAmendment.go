@@ -0,0 +1,159 @@
+package contract
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
+)
+
+var ProposeAmendment = tx.Transaction{
+	Tag:         "proposeAmendment",
+	Label:       "Propose Amendment",
+	Description: "Proposes adding, removing or changing the parameters of contract clauses, replacing its participants or finalizing or cancelling it. The proposal counts as the proposer approval",
+	Method:      "POST",
+
+	Args: []tx.Argument{
+		{
+			Required: true,
+			Tag:      "autoExecutableContract",
+			Label:    "Auto Executable Contract",
+			DataType: "->autoExecutableContract",
+		},
+		{
+			Required: true,
+			Tag:      "proposer",
+			Label:    "Proposer",
+			DataType: "->user",
+		},
+		{
+			Tag:      "description",
+			Label:    "Description",
+			DataType: "string",
+		},
+		{
+			Tag:         "addClauses",
+			Label:       "Add Clauses",
+			DataType:    "[]@object",
+			Description: "Clauses to add, in the same format as the addClauses transaction",
+		},
+		{
+			Tag:      "removeClauses",
+			Label:    "Remove Clauses",
+			DataType: "[]->clause",
+		},
+		{
+			Tag:         "parameterChanges",
+			Label:       "Parameter Changes",
+			DataType:    "[]@object",
+			Description: "Parameters to change on existing clauses, e.g. [{\"clause\": \"clauseId\", \"parameters\": {}}]",
+		},
+		{
+			Tag:         "participants",
+			Label:       "Participants",
+			DataType:    "[]->user",
+			Description: "New participants of the contract, replacing the current ones",
+		},
+		{
+			Tag:         "contractStatus",
+			Label:       "Contract Status",
+			DataType:    "contractStatus",
+			Description: "Status the contract moves to, finalized or cancelled",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		contractKey, ok := req["autoExecutableContract"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter 'autoExecutableContract' must be an asset key")
+		}
+
+		proposer, ok := req["proposer"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter 'proposer' must be an asset key")
+		}
+
+		contract, err := contractKey.Get(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get autoExecutableContract asset from ledger")
+		}
+
+		err = checkParty(stub, *contract, proposer)
+		if err != nil {
+			return nil, err
+		}
+
+		err = checkEditable(*contract)
+		if err != nil {
+			return nil, err
+		}
+
+		now, err := txTime(stub)
+		if err != nil {
+			return nil, err
+		}
+
+		amendment := map[string]interface{}{
+			"@assetType":             "amendment",
+			"id":                     stub.Stub.GetTxID(),
+			"autoExecutableContract": contractKey,
+			"proposer":               proposer,
+			"approvals":              []interface{}{proposer},
+			"status":                 AmendmentPending,
+			"proposedAt":             now.Format(time.RFC3339),
+		}
+		if description, ok := req["description"].(string); ok {
+			amendment["description"] = description
+		}
+		for _, field := range []string{"addClauses", "removeClauses", "parameterChanges"} {
+			if changes, ok := req[field].([]interface{}); ok && len(changes) > 0 {
+				amendment[field] = changes
+			}
+		}
+		// An empty list of participants removes every participant
+		if participants, ok := req["participants"].([]interface{}); ok {
+			amendment["participants"] = participants
+		}
+		if status, ok := req["contractStatus"].(datatypes.ContractStatusType); ok {
+			amendment["contractStatus"] = status
+		}
+		if amendment["addClauses"] == nil && amendment["removeClauses"] == nil && amendment["parameterChanges"] == nil &&
+			amendment["participants"] == nil && amendment["contractStatus"] == nil {
+			return nil, errors.NewCCError("Amendment must change at least one clause, the participants or the contract status", http.StatusBadRequest)
+		}
+
+		err = checkAmendmentChanges(stub, *contract, amendment)
+		if err != nil {
+			return nil, err
+		}
+
+		amendmentAsset, err := assets.NewAsset(amendment)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to create amendment asset")
+		}
+
+		res, err := amendmentAsset.PutNew(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to write amendment to the ledger")
+		}
+
+		// A contract with a single party is amended right away
+		if len(pendingApprovals(*contract, res)) == 0 {
+			res, err = applyApprovedAmendment(stub, contractKey, &amendmentAsset)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		responseJSON, nerr := json.Marshal(res)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "Failed to marshal response to JSON format")
+		}
+
+		return responseJSON, nil
+	},
+}
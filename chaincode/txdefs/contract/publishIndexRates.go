@@ -0,0 +1,117 @@
+package contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+var PublishIndexRates = tx.Transaction{
+	Tag:         "publishIndexRates",
+	Label:       "Publish Index Rates",
+	Description: "Publishes the monthly rates of an index, replacing rates already published for the same months",
+	Method:      "POST",
+	Callers:     []string{`org1MSP`, "orgMSP"},
+
+	Args: []tx.Argument{
+		{
+			Required:    true,
+			Tag:         "index",
+			Label:       "Index",
+			Description: "Index name, e.g. IPCA, IGP-M, SELIC or CDI",
+			DataType:    "string",
+		},
+		{
+			Required:    true,
+			Tag:         "rates",
+			Label:       "Rates",
+			Description: "Monthly rates in percent. e.g. [{\"date\": \"2024-01\", \"value\": 0.42}]",
+			DataType:    "[]@object",
+		},
+		{
+			Tag:         "source",
+			Label:       "Source",
+			Description: "Institution that published the rates, used when a rate has no source of its own",
+			DataType:    "string",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		index, _ := req["index"].(string)
+		index = strings.ToUpper(strings.TrimSpace(index))
+		if index == "" {
+			return nil, errors.NewCCError("Parameter 'index' must not be empty", http.StatusBadRequest)
+		}
+
+		rates, ok := req["rates"].([]interface{})
+		if !ok || len(rates) == 0 {
+			return nil, errors.NewCCError("Parameter 'rates' must be a non empty list", http.StatusBadRequest)
+		}
+
+		defaultSource, _ := req["source"].(string)
+
+		published := make([]map[string]interface{}, 0, len(rates))
+		months := make(map[string]bool)
+		for i, r := range rates {
+			rate, ok := r.(map[string]interface{})
+			if !ok {
+				return nil, errors.NewCCError(fmt.Sprintf("Rate %d must be an object", i), http.StatusBadRequest)
+			}
+
+			date, _ := rate["date"].(string)
+			month, nerr := utils.ParseMonth(date)
+			if nerr != nil {
+				return nil, errors.WrapErrorWithStatus(nerr, fmt.Sprintf("Invalid date on rate %d", i), http.StatusBadRequest)
+			}
+
+			monthStr := month.Format(time.RFC3339)
+			if months[monthStr] {
+				return nil, errors.NewCCError(fmt.Sprintf("Rate for %s is duplicated", month.Format("2006-01")), http.StatusBadRequest)
+			}
+			months[monthStr] = true
+
+			value, ok := rate["value"].(float64)
+			if !ok {
+				return nil, errors.NewCCError(fmt.Sprintf("Value of rate %d must be a number", i), http.StatusBadRequest)
+			}
+
+			indexRateMap := map[string]interface{}{
+				"@assetType": "indexRate",
+				"index":      index,
+				"date":       monthStr,
+				"value":      value,
+			}
+			if source, ok := rate["source"].(string); ok && source != "" {
+				indexRateMap["source"] = source
+			} else if defaultSource != "" {
+				indexRateMap["source"] = defaultSource
+			}
+
+			indexRateAsset, err := assets.NewAsset(indexRateMap)
+			if err != nil {
+				return nil, errors.WrapError(err, "Failed to create index rate asset")
+			}
+
+			res, err := indexRateAsset.Put(stub)
+			if err != nil {
+				return nil, errors.WrapError(err, "Error saving index rate on blockchain")
+			}
+
+			published = append(published, res)
+		}
+
+		responseJSON, nerr := json.Marshal(published)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "Failed to marshal response to JSON format")
+		}
+
+		return responseJSON, nil
+	},
+}
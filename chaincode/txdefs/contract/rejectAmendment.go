@@ -0,0 +1,103 @@
+package contract
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+var RejectAmendment = tx.Transaction{
+	Tag:         "rejectAmendment",
+	Label:       "Reject Amendment",
+	Description: "Rejects an amendment, which is then never applied",
+	Method:      "POST",
+
+	Args: []tx.Argument{
+		{
+			Required: true,
+			Tag:      "amendment",
+			Label:    "Amendment",
+			DataType: "->amendment",
+		},
+		{
+			Required: true,
+			Tag:      "user",
+			Label:    "User",
+			DataType: "->user",
+		},
+		{
+			Tag:      "reason",
+			Label:    "Reason",
+			DataType: "string",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		amendmentKey, ok := req["amendment"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter 'amendment' must be an asset key")
+		}
+
+		user, ok := req["user"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter 'user' must be an asset key")
+		}
+
+		amendment, err := amendmentKey.Get(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get amendment")
+		}
+
+		if status, _ := (*amendment)["status"].(string); status != AmendmentPending {
+			return nil, errors.NewCCError("Amendment is already "+status, http.StatusBadRequest)
+		}
+
+		contractRef, _ := (*amendment)["autoExecutableContract"].(map[string]interface{})
+		contractKey, err := assets.NewKey(contractRef)
+		if err != nil {
+			return nil, errors.WrapError(err, "Invalid amendment contract")
+		}
+
+		contract, err := contractKey.Get(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get contract")
+		}
+
+		err = checkParty(stub, *contract, user)
+		if err != nil {
+			return nil, err
+		}
+
+		now, err := txTime(stub)
+		if err != nil {
+			return nil, err
+		}
+
+		rejection := map[string]interface{}{
+			"user":      user,
+			"timestamp": now.Format(time.RFC3339),
+		}
+		if reason, ok := req["reason"].(string); ok {
+			rejection["reason"] = reason
+		}
+
+		res, err := amendment.Update(stub, map[string]interface{}{
+			"status":    AmendmentRejected,
+			"rejection": rejection,
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to update amendment")
+		}
+
+		responseJSON, nerr := json.Marshal(res)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "Failed to marshal response to JSON format")
+		}
+
+		return responseJSON, nil
+	},
+}
@@ -42,63 +42,101 @@ var RemoveClause = tx.Transaction{
 			return nil, errors.WrapError(err, "Failed to get autoExecutableContract asset from ledger")
 		}
 
-		clauseKey, ok := req["clause"].(assets.Key)
-		if !ok {
-			return nil, errors.WrapError(nil, "Parameter 'clause' must be an asset key")
+		err = checkOwner(stub, *contractAsset)
+		if err != nil {
+			return nil, err
 		}
 
-		clauseAsset, err := clauseKey.Get(stub)
+		err = checkDirectEdit(*contractAsset)
 		if err != nil {
-			return nil, errors.WrapError(err, "Failed to get clause asset from ledger")
+			return nil, err
 		}
 
-		clauses, ok := (*contractAsset)["clauses"].([]interface{})
+		return removeClause(stub, req)
+	},
+}
+
+// removeClause detaches the clause from the contract and deletes it when nothing references it.
+// Callers check who may change the contract.
+func removeClause(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+	contractKey, ok := req["autoExecutableContract"].(assets.Key)
+	if !ok {
+		return nil, errors.WrapError(nil, "Parameter 'contract' must be an asset key")
+	}
+
+	contractAsset, err := contractKey.Get(stub)
+	if err != nil {
+		return nil, errors.WrapError(err, "Failed to get autoExecutableContract asset from ledger")
+	}
+
+	err = checkEditable(*contractAsset)
+	if err != nil {
+		return nil, err
+	}
+
+	clauseKey, ok := req["clause"].(assets.Key)
+	if !ok {
+		return nil, errors.WrapError(nil, "Parameter 'clause' must be an asset key")
+	}
+
+	clauseAsset, err := clauseKey.Get(stub)
+	if err != nil {
+		return nil, errors.WrapError(err, "Failed to get clause asset from ledger")
+	}
+
+	clauses, ok := (*contractAsset)["clauses"].([]interface{})
+	if !ok {
+		return nil, errors.WrapError(nil, "Clauses field is not an array")
+	}
+
+	var updatedClauses []interface{}
+	var clauseFound bool
+	for _, c := range clauses {
+		clauseMap, ok := c.(map[string]interface{})
 		if !ok {
-			return nil, errors.WrapError(nil, "Clauses field is not an array")
+			continue
 		}
-
-		var updatedClauses []interface{}
-		var clauseFound bool
-		for _, c := range clauses {
-			clauseMap, ok := c.(map[string]interface{})
-			if !ok {
-				continue
-			}
-			if clauseMap["@key"] != (*clauseAsset)["@key"] {
-				updatedClauses = append(updatedClauses, c)
-			} else {
-				clauseFound = true
-			}
+		if clauseMap["@key"] != (*clauseAsset)["@key"] {
+			updatedClauses = append(updatedClauses, c)
+		} else {
+			clauseFound = true
 		}
+	}
 
-		if !clauseFound {
-			return nil, errors.NewCCError("Clause does not belong to contract", http.StatusBadRequest)
-		}
+	if !clauseFound {
+		return nil, errors.NewCCError("Clause does not belong to contract", http.StatusBadRequest)
+	}
 
-		if len(updatedClauses) == 0 {
-			updatedClauses = []interface{}{}
-		}
+	if len(updatedClauses) == 0 {
+		updatedClauses = []interface{}{}
+	}
 
-		contractUpdates := map[string]interface{}{
-			"clauses": updatedClauses,
-		}
+	contractUpdates := map[string]interface{}{
+		"clauses": updatedClauses,
+	}
 
-		updatedContractAsset, err := contractKey.Update(stub, contractUpdates)
-		if err != nil {
-			return nil, errors.WrapError(err, "Failed to update autoExecutableContract asset in ledger")
-		}
+	updatedContractAsset, err := contractKey.Update(stub, contractUpdates)
+	if err != nil {
+		return nil, errors.WrapError(err, "Failed to update autoExecutableContract asset in ledger")
+	}
+
+	// Clauses referenced by execution records or payments stay on the ledger, only detached from the contract
+	referenced, err := clauseKey.IsReferenced(stub)
+	if err != nil {
+		return nil, errors.WrapError(err, "Failed to check clause references")
+	}
 
+	if !referenced {
 		_, err = clauseKey.Delete(stub)
 		if err != nil {
 			return nil, errors.WrapError(err, "Failed to delete clause")
 		}
+	}
 
-		updatedContractJSON, nerr := json.Marshal(updatedContractAsset)
-		if nerr != nil {
-			return nil, errors.WrapError(nil, "Failed to marshal updated contract asset")
-		}
-
-		return updatedContractJSON, nil
+	updatedContractJSON, nerr := json.Marshal(updatedContractAsset)
+	if nerr != nil {
+		return nil, errors.WrapError(nil, "Failed to marshal updated contract asset")
+	}
 
-	},
+	return updatedContractJSON, nil
 }
@@ -0,0 +1,120 @@
+package contract
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+// contractSortFields maps the fields contracts can be sorted by to their CouchDB index
+var contractSortFields = map[string]string{
+	"name":          "indexName",
+	"signatureDate": "indexSignatureDate",
+	"status":        "indexStatus",
+}
+
+// activeContractSelector matches active contracts, including the ones created before the status lifecycle
+func activeContractSelector() []interface{} {
+	return []interface{}{
+		map[string]interface{}{"status": datatypes.ContractActive},
+		map[string]interface{}{"status": map[string]interface{}{"$exists": false}},
+	}
+}
+
+var SearchContracts = tx.Transaction{
+	Tag:         "searchContracts",
+	Label:       "Search Contracts",
+	Description: "Returns a page of contracts filtered by owner, participant, status and signature date range",
+	Method:      "GET",
+	ReadOnly:    true,
+
+	Args: append([]tx.Argument{
+		{
+			Tag:      "owner",
+			Label:    "Owner",
+			DataType: "->user",
+		},
+		{
+			Tag:      "participant",
+			Label:    "Participant",
+			DataType: "->user",
+		},
+		{
+			Tag:      "status",
+			Label:    "Status",
+			DataType: "contractStatus",
+		},
+		{
+			Tag:         "signatureDateFrom",
+			Label:       "Signature Date From",
+			DataType:    "datetime",
+			Description: "Only contracts signed at or after this date",
+		},
+		{
+			Tag:         "signatureDateTo",
+			Label:       "Signature Date To",
+			DataType:    "datetime",
+			Description: "Only contracts signed at or before this date",
+		},
+	}, utils.PaginationArgs...),
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		selector := map[string]interface{}{
+			"@assetType": "autoExecutableContract",
+		}
+
+		if owner, ok := req["owner"].(assets.Key); ok {
+			selector["owner.@key"] = owner.Key()
+		}
+
+		if participant, ok := req["participant"].(assets.Key); ok {
+			selector["participants"] = map[string]interface{}{
+				"$elemMatch": map[string]interface{}{
+					"@key": participant.Key(),
+				},
+			}
+		}
+
+		if status, ok := req["status"].(datatypes.ContractStatusType); ok {
+			if status == datatypes.ContractActive {
+				selector["$or"] = activeContractSelector()
+			} else {
+				selector["status"] = status
+			}
+		}
+
+		signatureDate := map[string]interface{}{}
+		if from, ok := req["signatureDateFrom"].(time.Time); ok {
+			signatureDate["$gte"] = from.Format(time.RFC3339)
+		}
+		if to, ok := req["signatureDateTo"].(time.Time); ok {
+			signatureDate["$lte"] = to.Format(time.RFC3339)
+		}
+		if len(signatureDate) > 0 {
+			selector["signatureDate"] = signatureDate
+		}
+
+		query, err := utils.PaginatedQuery(req, selector, contractSortFields)
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := assets.Search(stub, query, "", false)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "error searching for contracts", http.StatusInternalServerError)
+		}
+
+		responseJSON, nerr := json.Marshal(response)
+		if nerr != nil {
+			return nil, errors.WrapErrorWithStatus(nerr, "error marshaling response", http.StatusInternalServerError)
+		}
+
+		return responseJSON, nil
+	},
+}
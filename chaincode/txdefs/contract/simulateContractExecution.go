@@ -0,0 +1,179 @@
+package contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/txdefs/contract/models"
+)
+
+var SimulateContractExecution = tx.Transaction{
+	Tag:         "simulateContractExecution",
+	Label:       "Simulate Contract Execution",
+	Description: "Runs the contract clauses with hypothetical inputs and date without writing anything to the ledger",
+	Method:      "GET",
+	ReadOnly:    true,
+
+	Args: []tx.Argument{
+		{
+			Required: true,
+			Tag:      "contract",
+			Label:    "Contract",
+			DataType: "->autoExecutableContract",
+		},
+		{
+			Tag:         "inputs",
+			Label:       "Inputs",
+			Description: "Inputs that override the stored clause inputs, indexed by clause id. e.g. {\"clauseId\": {\"evaluatedDate\": \"2024-05-15T00:00:00Z\"}}",
+			DataType:    "@object",
+		},
+		{
+			Tag:         "asOf",
+			Label:       "As Of",
+			Description: "Date the execution is simulated at. Defaults to now",
+			DataType:    "datetime",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		contractKey, ok := req["contract"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter 'contract' must be an asset key")
+		}
+
+		contract, err := models.GetAutoExecutableContract(stub, contractKey)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get auto executable contract")
+		}
+
+		// The simulation reveals the contract data, so it is restricted like the execution itself
+		err = checkParticipant(stub, *contract.Asset)
+		if err != nil {
+			return nil, err
+		}
+
+		opts := executionOptions{
+			dryRun: true,
+			inputs: make(map[string]map[string]interface{}),
+		}
+
+		if asOf, ok := req["asOf"].(time.Time); ok {
+			opts.asOf = asOf.UTC().Format(time.RFC3339)
+		}
+
+		if inputs, ok := req["inputs"].(map[string]interface{}); ok {
+			for ref, value := range inputs {
+				clauseInputs, ok := value.(map[string]interface{})
+				if !ok {
+					return nil, errors.NewCCError(fmt.Sprintf("Inputs of clause %s must be an object", ref), http.StatusBadRequest)
+				}
+
+				clause := findClause(contract, ref)
+				if clause == nil {
+					return nil, errors.NewCCError(fmt.Sprintf("Clause %s does not belong to the contract", ref), http.StatusBadRequest)
+				}
+				opts.inputs[clause.Key] = clauseInputs
+			}
+		}
+
+		originalData, nerr := copyData(contract.Data)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "Failed to copy contract data")
+		}
+		contract.Data, nerr = copyData(contract.Data)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "Failed to copy contract data")
+		}
+
+		results, skippedClauses, err := executeClauses(stub, contract, contract.Clauses, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		finalData, nerr := copyData(contract.Data)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "Failed to copy resulting contract data")
+		}
+
+		var generatedAssets []map[string]interface{}
+		for _, r := range results {
+			generatedAssets = append(generatedAssets, r.Assets...)
+		}
+
+		responseJSON, nerr := json.Marshal(map[string]interface{}{
+			"clauses":        results,
+			"skippedClauses": skippedClauses,
+			"data":           finalData,
+			"dataDiff":       diffData(originalData, finalData),
+			"assets":         generatedAssets,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "Failed to marshal response to JSON format")
+		}
+
+		return responseJSON, nil
+	},
+}
+
+// findClause returns the contract clause with the given id or key
+func findClause(contract *models.AutoExecutableContract, ref string) *models.Clause {
+	for _, clause := range contract.Clauses {
+		if clause.Id == ref || clause.Key == ref {
+			return clause
+		}
+	}
+	return nil
+}
+
+// copyData returns a deep copy of the data with the values in their JSON form
+func copyData(data map[string]interface{}) (map[string]interface{}, error) {
+	dataBytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	copied := make(map[string]interface{})
+	err = json.Unmarshal(dataBytes, &copied)
+	if err != nil {
+		return nil, err
+	}
+	return copied, nil
+}
+
+// diffData lists the top level data entries added, changed and removed
+func diffData(before, after map[string]interface{}) map[string]interface{} {
+	added := make(map[string]interface{})
+	changed := make(map[string]interface{})
+	removed := []string{}
+
+	for k, v := range after {
+		previous, exists := before[k]
+		if !exists {
+			added[k] = v
+		} else if !reflect.DeepEqual(previous, v) {
+			changed[k] = map[string]interface{}{
+				"before": previous,
+				"after":  v,
+			}
+		}
+	}
+	for k := range before {
+		if _, exists := after[k]; !exists {
+			removed = append(removed, k)
+		}
+	}
+	sort.Strings(removed)
+
+	return map[string]interface{}{
+		"added":   added,
+		"changed": changed,
+		"removed": removed,
+	}
+}
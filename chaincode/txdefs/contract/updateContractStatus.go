@@ -0,0 +1,120 @@
+package contract
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/eventtypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/txdefs/contract/models"
+)
+
+var UpdateContractStatus = tx.Transaction{
+	Tag:         "updateContractStatus",
+	Label:       "Update Contract Status",
+	Description: "Moves a contract to a new status. Drafts can be activated or cancelled, active contracts suspended, finalized, cancelled or expired and suspended contracts reactivated, cancelled or expired. Binding contracts with participants are finalized or cancelled through an amendment",
+	Method:      "POST",
+
+	Args: []tx.Argument{
+		{
+			Required: true,
+			Tag:      "contract",
+			Label:    "Contract",
+			DataType: "->autoExecutableContract",
+		},
+		{
+			Required: true,
+			Tag:      "status",
+			Label:    "Status",
+			DataType: "contractStatus",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		contractKey, ok := req["contract"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter 'contract' must be an asset key")
+		}
+
+		status, ok := req["status"].(datatypes.ContractStatusType)
+		if !ok {
+			return nil, errors.NewCCError("Parameter 'status' must be a contract status", http.StatusBadRequest)
+		}
+
+		contract, err := contractKey.Get(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get contract")
+		}
+
+		err = checkOwner(stub, *contract)
+		if err != nil {
+			return nil, err
+		}
+
+		current := models.ContractStatus(*contract)
+		err = checkTransition(current, status)
+		if err != nil {
+			return nil, err
+		}
+
+		// Participants of a binding contract must agree to finalize or cancel it through an amendment
+		if status == datatypes.ContractFinalized || status == datatypes.ContractCancelled {
+			err = checkDirectEdit(*contract)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Contracts linked to a document wait for it to be finalized before becoming active
+		if status == datatypes.ContractPendingSignature || (current == datatypes.ContractPendingSignature && status == datatypes.ContractActive) {
+			return nil, errors.NewCCError("Contracts are activated once their document is finalized", http.StatusBadRequest)
+		}
+		update := map[string]interface{}{
+			"status": status,
+		}
+		if current == datatypes.ContractDraft && status == datatypes.ContractActive {
+			linked, signedAt, err := linkedStatus(stub, *contract)
+			if err != nil {
+				return nil, err
+			}
+			status = linked
+			update = signedContractUpdate(*contract, status, signedAt)
+		}
+
+		ended, err := contractEnded(stub, *contract)
+		if err != nil {
+			return nil, err
+		}
+		if ended && status == datatypes.ContractActive {
+			return nil, errors.NewCCError("Contract end date has passed, it cannot be activated", http.StatusBadRequest)
+		}
+		if !ended && status == datatypes.ContractExpired {
+			return nil, errors.NewCCError("Contract end date has not passed yet", http.StatusBadRequest)
+		}
+
+		updatedContract, err := contractKey.Update(stub, update)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to update contract")
+		}
+
+		name, _ := updatedContract["name"].(string)
+		err = eventtypes.Emit(stub, eventtypes.ContractStatusChanged, eventtypes.ContractEvent{
+			Contract: contractKey.Key(),
+			Name:     name,
+			Status:   status.String(),
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to emit contract status event")
+		}
+
+		responseJSON, nerr := json.Marshal(updatedContract)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "Failed to marshal response to JSON format")
+		}
+
+		return responseJSON, nil
+	},
+}
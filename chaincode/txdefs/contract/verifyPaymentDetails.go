@@ -0,0 +1,64 @@
+package contract
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+var VerifyPaymentDetails = tx.Transaction{
+	Tag:         "verifyPaymentDetails",
+	Label:       "Verify Payment Details",
+	Description: "Checks the payment details sent through the transient map against the hash stored on the payment",
+	Method:      "GET",
+	ReadOnly:    true,
+
+	Args: append([]tx.Argument{
+		{
+			Required: true,
+			Tag:      "payment",
+			Label:    "Payment",
+			DataType: "->payment",
+		},
+	}, paymentDetailsArgs...),
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		paymentKey, ok := req["payment"].(assets.Key)
+		if !ok {
+			return nil, errors.NewCCError("Invalid payment format", http.StatusBadRequest)
+		}
+
+		paymentAsset, err := paymentKey.Get(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get payment asset from ledger")
+		}
+
+		storedHash, ok := (*paymentAsset)["detailsHash"].(string)
+		if !ok {
+			return nil, errors.NewCCError("Payment has no private details", http.StatusBadRequest)
+		}
+
+		details := paymentDetails(req)
+		if details == nil {
+			return nil, errors.NewCCError("No payment details to verify", http.StatusBadRequest)
+		}
+
+		salt, _ := req["salt"].(string)
+		hash, err := paymentDetailsHash(salt, details)
+		if err != nil {
+			return nil, err
+		}
+
+		response, nerr := json.Marshal(map[string]interface{}{
+			"valid": hash == storedHash,
+		})
+		if nerr != nil {
+			return nil, errors.WrapErrorWithStatus(nerr, "Failed to marshal response", http.StatusInternalServerError)
+		}
+
+		return response, nil
+	},
+}
@@ -0,0 +1,250 @@
+package document
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/eventtypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+// Rules applied to the signatures of the amended document
+const (
+	SignaturesReset     = "reset"
+	SignaturesCarryOver = "carryOver"
+)
+
+var AmendDocument = tx.Transaction{
+	Tag:         "amendDocument",
+	Label:       "Amend Document",
+	Description: "Creates a new version of a document linked to the hash of the version it amends",
+	Method:      "POST",
+
+	Args: []tx.Argument{
+		{
+			Tag:         "document",
+			Label:       "Document",
+			Required:    true,
+			DataType:    "->document",
+			Description: "Latest version of the document",
+		},
+		{
+			Tag:      "originalHash",
+			Label:    "originalHash",
+			Required: true,
+			DataType: "sha256",
+		},
+		{
+			Tag:      "originalDocURL",
+			Label:    "originalDocURL",
+			Required: true,
+			DataType: "string",
+		},
+		{
+			Tag:         "name",
+			Label:       "name",
+			DataType:    "string",
+			Description: "Defaults to the name of the amended version",
+		},
+		{
+			Tag:         "requiredSignatures",
+			Label:       "requiredSignatures",
+			DataType:    "[]->user",
+			Description: "Defaults to the required signers of the amended version",
+		},
+		{
+			Tag:         "signingOrder",
+			Label:       "Signing Order",
+			DataType:    "[]@object",
+			Description: "Defaults to the signing order of the amended version when the required signers are kept",
+		},
+		{
+			Tag:         "quorums",
+			Label:       "Quorums",
+			DataType:    "[]@object",
+			Description: "Defaults to the quorums of the amended version when the required signers are kept",
+		},
+		{
+			Tag:      "timeout",
+			Label:    "Timeout",
+			DataType: "datetime",
+		},
+		{
+			Tag:         "signatures",
+			Label:       "Signatures",
+			DataType:    "string",
+			Description: "reset (default) requires every signer to sign the new version, carryOver records the signers of the amended version still required, who confirm the new version by signing it regardless of the signing order",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		previousKey, ok := req["document"].(assets.Key)
+		if !ok {
+			return nil, errors.NewCCError("Failed to get document parameter", 400)
+		}
+
+		originalHash, ok := req["originalHash"].(string)
+		if !ok {
+			return nil, errors.NewCCError("Failed to get originalHash parameter", 400)
+		}
+
+		originalDocURL, ok := req["originalDocURL"].(string)
+		if !ok {
+			return nil, errors.NewCCError("Failed to get originalDocURL parameter", 400)
+		}
+
+		rule := SignaturesReset
+		if r, ok := req["signatures"].(string); ok && r != "" {
+			if r != SignaturesReset && r != SignaturesCarryOver {
+				return nil, errors.NewCCError("Parameter 'signatures' must be reset or carryOver", 400)
+			}
+			rule = r
+		}
+
+		previous, err := previousKey.Get(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get document from the ledger")
+		}
+		previousMap := *previous
+
+		// Only the owner amends a document
+		err = utils.CheckCallerIsUser(stub, previousMap["owner"])
+		if err != nil {
+			return nil, err
+		}
+
+		if previousMap["supersededBy"] != nil {
+			return nil, errors.NewCCError("Document was already amended, amend its latest version", 400)
+		}
+		if status, _ := datatypes.ParseStatus(previousMap["status"]); status == 1 || status == 2 {
+			return nil, errors.NewCCError("Cancelled or expired documents cannot be amended", 400)
+		}
+
+		version := 1.0
+		if v, ok := previousMap["version"].(float64); ok {
+			version = v
+		}
+
+		name, ok := req["name"].(string)
+		if !ok || name == "" {
+			name, _ = previousMap["name"].(string)
+		}
+
+		requiredSignatures, ok := req["requiredSignatures"].([]interface{})
+		signingOrder, _ := req["signingOrder"].([]interface{})
+		quorums, _ := req["quorums"].([]interface{})
+		if !ok || len(requiredSignatures) == 0 {
+			requiredSignatures, _ = previousMap["requiredSignatures"].([]interface{})
+			if signingOrder == nil {
+				signingOrder, _ = previousMap["signingOrder"].([]interface{})
+			}
+			if quorums == nil {
+				quorums, _ = previousMap["quorums"].([]interface{})
+			}
+		}
+
+		// Carried over signers never signed the new hash, so they are only recorded and must still confirm it
+		var carriedOver []interface{}
+		if rule == SignaturesCarryOver {
+			required := make(map[string]bool)
+			for _, key := range utils.RefKeys(requiredSignatures) {
+				required[key] = true
+			}
+			for _, key := range utils.RefKeys(previousMap["successfulSignatures"]) {
+				if required[key] {
+					carriedOver = append(carriedOver, map[string]interface{}{
+						"@assetType": "user",
+						"@key":       key,
+					})
+				}
+			}
+		}
+
+		doc := map[string]interface{}{
+			"@assetType":         "document",
+			"originalHash":       originalHash,
+			"status":             datatypes.StatusType(0),
+			"requiredSignatures": requiredSignatures,
+			"originalDocURL":     originalDocURL,
+			"name":               name,
+			"owner":              previousMap["owner"],
+			"version":            version + 1,
+			"previousVersion":    previousKey,
+			"previousHash":       previousMap["originalHash"],
+		}
+		if timeout, ok := req["timeout"].(time.Time); ok {
+			doc["timeout"] = timeout.UTC()
+		}
+		if rejectionPolicy, ok := previousMap["rejectionPolicy"].(string); ok {
+			doc["rejectionPolicy"] = rejectionPolicy
+		}
+		if len(quorums) > 0 {
+			parsed, err := parseQuorums(quorums)
+			if err != nil {
+				return nil, err
+			}
+			err = checkQuorums(parsed, requiredSignatures)
+			if err != nil {
+				return nil, err
+			}
+			doc["quorums"] = quorums
+		}
+		if len(carriedOver) > 0 {
+			doc["carriedOverSignatures"] = carriedOver
+		}
+		if len(signingOrder) > 0 {
+			order, err := parseSigningOrder(signingOrder)
+			if err != nil {
+				return nil, err
+			}
+			err = checkSigningOrder(order, requiredSignatures)
+			if err != nil {
+				return nil, err
+			}
+			doc["signingOrder"] = signingOrder
+		}
+
+		document, err := assets.NewAsset(doc)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to create asset")
+		}
+
+		newDocument, err := document.PutNew(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to write asset to the ledger")
+		}
+
+		_, err = previousKey.Update(stub, map[string]interface{}{
+			"supersededBy": map[string]interface{}{
+				"@assetType": "document",
+				"@key":       newDocument["@key"],
+			},
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to link the amended version")
+		}
+
+		newKey, _ := newDocument["@key"].(string)
+		// A new version always waits for signatures, so linked contracts wait for it to be signed again
+		err = updateLinkedContracts(stub, previousKey.Key(), newKey, false)
+		if err != nil {
+			return nil, err
+		}
+
+		err = eventtypes.Emit(stub, eventtypes.DocumentAmended, documentEvent(newDocument, ""))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to emit document amended event")
+		}
+
+		resBytes, nerr := json.Marshal(newDocument)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		return resBytes, nil
+	},
+}
@@ -0,0 +1,126 @@
+package document
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+var BindUserIdentity = tx.Transaction{
+	Tag:         "bindUserIdentity",
+	Label:       "Bind User Identity",
+	Description: "Binds a user to a client identity, for users created before identities were bound or whose certificate changed. Only an admin of the identity MSP can bind it, and a user never bound can only be bound to its home organization",
+	Method:      "POST",
+
+	Args: []tx.Argument{
+		{
+			Tag:      "user",
+			Label:    "User",
+			Required: true,
+			DataType: "->user",
+		},
+		{
+			Tag:      "mspId",
+			Label:    "MSP ID",
+			Required: true,
+			DataType: "string",
+		},
+		{
+			Tag:      "enrollmentId",
+			Label:    "Enrollment ID",
+			Required: true,
+			DataType: "string",
+		},
+		{
+			Tag:         "certFingerprint",
+			Label:       "Certificate Fingerprint",
+			DataType:    "string",
+			Description: "SHA-256 fingerprint of the only certificate accepted for the user",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		userKey, ok := req["user"].(assets.Key)
+		if !ok {
+			return nil, errors.NewCCError("Failed to get user parameter", http.StatusBadRequest)
+		}
+
+		mspId, _ := req["mspId"].(string)
+		enrollmentId, _ := req["enrollmentId"].(string)
+		if mspId == "" || enrollmentId == "" {
+			return nil, errors.NewCCError("mspId and enrollmentId must not be empty", http.StatusBadRequest)
+		}
+
+		err := utils.CheckCallerIsAdmin(stub, mspId)
+		if err != nil {
+			return nil, err
+		}
+
+		user, err := userKey.Get(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get user from the ledger")
+		}
+
+		if (*user)["company"] != nil {
+			return nil, errors.NewCCError("Companies act through their legal representatives", http.StatusBadRequest)
+		}
+		if erased, _ := (*user)["erased"].(bool); erased {
+			return nil, errors.NewCCError("User is erased", http.StatusBadRequest)
+		}
+
+		// Admins only move users between identities of their own MSP, and a user never bound is
+		// bound first by an admin of the org that wrote it
+		if current, _ := (*user)["mspId"].(string); current != "" {
+			if current != mspId {
+				return nil, errors.NewCCError("User is bound to an identity of another MSP", http.StatusForbidden)
+			}
+		} else {
+			home, _ := (*user)["homeMspId"].(string)
+			if home == "" {
+				home, _ = (*user)["@lastTouchBy"].(string)
+			}
+			if home != mspId {
+				return nil, errors.NewCCError("Only an admin of the user's home organization can bind it to a first identity", http.StatusForbidden)
+			}
+		}
+
+		// The user is rewritten under the same key so a certificate pinned on the previous binding is dropped
+		bound := map[string]interface{}{
+			"@assetType": "user",
+			"@key":       userKey.Key(),
+		}
+		for prop, value := range *user {
+			if !strings.HasPrefix(prop, "@") {
+				bound[prop] = value
+			}
+		}
+		bound["mspId"] = mspId
+		bound["enrollmentId"] = enrollmentId
+		delete(bound, "certFingerprint")
+		if certFingerprint, _ := req["certFingerprint"].(string); certFingerprint != "" {
+			bound["certFingerprint"] = certFingerprint
+		}
+
+		boundAsset, err := assets.NewAsset(bound)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to create user asset")
+		}
+
+		updated, err := boundAsset.Put(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to write user to the ledger")
+		}
+
+		resBytes, nerr := json.Marshal(updated)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		return resBytes, nil
+	},
+}
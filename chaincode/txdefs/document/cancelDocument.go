@@ -8,6 +8,8 @@ import (
 	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
 	tx "github.com/hyperledger-labs/cc-tools/transactions"
 	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/eventtypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
 )
 
 var CancelDocument = tx.Transaction{
@@ -49,7 +51,13 @@ var CancelDocument = tx.Transaction{
 
 		documentMap := *documentAsset
 
-		currentStatus, ok := documentMap["status"].(datatypes.StatusType)
+		// Only the owner can cancel or expire a document
+		err = utils.CheckCallerIsUser(stub, documentMap["owner"])
+		if err != nil {
+			return nil, err
+		}
+
+		currentStatus, ok := datatypes.ParseStatus(documentMap["status"])
 		if ok && ((currentStatus == 1 && status == 1) || (currentStatus == 2 && status == 2)) {
 			var statusMessage string
 			switch currentStatus {
@@ -74,6 +82,15 @@ var CancelDocument = tx.Transaction{
 			return nil, errors.WrapError(err, "Failed to update document asset in the ledger")
 		}
 
+		event := eventtypes.DocumentCancelled
+		if status == 2 {
+			event = eventtypes.DocumentExpired
+		}
+		err = eventtypes.Emit(stub, event, documentEvent(updatedDocument, ""))
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to emit document event")
+		}
+
 		// Marshal the updated document asset to JSON format
 		updatedDocumentJSON, e := json.Marshal(updatedDocument)
 		if e != nil {
@@ -0,0 +1,35 @@
+package document
+
+import (
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+// checkRepresentatives fails unless the representatives are natural persons whose data was not erased
+func checkRepresentatives(stub *sw.StubWrapper, representatives []interface{}) errors.ICCError {
+	keys := utils.RefKeys(representatives)
+	if len(keys) == 0 {
+		return errors.NewCCError("A company must have at least one legal representative", http.StatusBadRequest)
+	}
+
+	for _, key := range keys {
+		representativeKey := assets.Key{"@assetType": "user", "@key": key}
+		representative, err := representativeKey.Get(stub)
+		if err != nil {
+			return errors.WrapError(err, "Failed to get representative from the ledger")
+		}
+
+		if (*representative)["company"] != nil {
+			return errors.NewCCError("Legal representatives must be natural persons", http.StatusBadRequest)
+		}
+		if erased, _ := (*representative)["erased"].(bool); erased {
+			return errors.NewCCError("Erased users cannot be legal representatives", http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package document
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+var CreateCompany = tx.Transaction{
+	Tag:         "createCompany",
+	Label:       "Create Company",
+	Description: "Registers a legal entity and its party user, which contracts and documents reference as owner, participant or signer",
+	Method:      "POST",
+
+	Args: []tx.Argument{
+		{
+			Tag:      "cnpj",
+			Label:    "CNPJ",
+			Required: true,
+			DataType: "cnpj",
+		},
+		{
+			Tag:      "legalName",
+			Label:    "Legal Name",
+			Required: true,
+			DataType: "string",
+		},
+		{
+			Tag:      "tradeName",
+			Label:    "Trade Name",
+			DataType: "string",
+		},
+		{
+			Tag:         "representatives",
+			Label:       "Legal Representatives",
+			Required:    true,
+			DataType:    "[]->user",
+			Description: "The caller must be one of them",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		cnpj, _ := req["cnpj"].(string)
+		legalName, _ := req["legalName"].(string)
+		if legalName == "" {
+			return nil, errors.NewCCError("Failed to get legalName parameter", http.StatusBadRequest)
+		}
+
+		representatives, _ := req["representatives"].([]interface{})
+		err := checkRepresentatives(stub, representatives)
+		if err != nil {
+			return nil, err
+		}
+
+		// Only a legal representative registers the company
+		err = utils.CheckCallerIsAnyUser(stub, representatives)
+		if err != nil {
+			return nil, err
+		}
+
+		company := map[string]interface{}{
+			"@assetType":      "company",
+			"cnpj":            cnpj,
+			"legalName":       legalName,
+			"representatives": representatives,
+		}
+		if tradeName, ok := req["tradeName"].(string); ok && tradeName != "" {
+			company["tradeName"] = tradeName
+		}
+
+		companyAsset, err := assets.NewAsset(company)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to create company asset")
+		}
+
+		companyRes, err := companyAsset.PutNew(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to write company to the ledger")
+		}
+
+		partyAsset, err := assets.NewAsset(map[string]interface{}{
+			"@assetType": "user",
+			"id":         companyAsset.Key(),
+			"company": map[string]interface{}{
+				"@assetType": "company",
+				"@key":       companyAsset.Key(),
+			},
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to create company party asset")
+		}
+
+		partyRes, err := partyAsset.PutNew(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to write company party to the ledger")
+		}
+
+		resBytes, nerr := json.Marshal(map[string]interface{}{
+			"company": companyRes,
+			"party":   partyRes,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		return resBytes, nil
+	},
+}
@@ -7,12 +7,13 @@ import (
 	"github.com/hyperledger-labs/cc-tools/errors"
 	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
 	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
 )
 
 var CreateSigner = tx.Transaction{
 	Tag:         "createSigner",
 	Label:       "Create Signer",
-	Description: "",
+	Description: "Creates a user. CPF, email, name, phone and user name must be sent through the transient map and are kept on the personalData collection",
 	Method:      "POST",
 
 	Args: []tx.Argument{
@@ -21,30 +22,48 @@ var CreateSigner = tx.Transaction{
 			Label:    "Cpf",
 			Required: true,
 			DataType: "cpf",
+			Private:  true,
 		},
 		{
 			Tag:      "email",
 			Label:    "Email",
 			Required: true,
 			DataType: "string",
+			Private:  true,
 		},
 		{
 			Tag:      "name",
 			Label:    "Name",
 			Required: true,
 			DataType: "string",
+			Private:  true,
 		},
 		{
 			Tag:      "phone",
 			Label:    "Phone",
 			Required: true,
 			DataType: "string",
+			Private:  true,
+		},
+		{
+			Tag:         "privacyPolicyVersion",
+			Label:       "Privacy Policy Version",
+			Required:    true,
+			DataType:    "string",
+			Description: "Version of the privacy policy the user consented to",
 		},
 		{
 			Tag:      "userName",
 			Label:    "UserName",
 			Required: true,
 			DataType: "string",
+			Private:  true,
+		},
+		{
+			Tag:         "pinCertificate",
+			Label:       "Pin Certificate",
+			DataType:    "boolean",
+			Description: "Only accept the caller certificate for the user, not other certificates enrolled with the same ID",
 		},
 	},
 
@@ -74,13 +93,33 @@ var CreateSigner = tx.Transaction{
 			return nil, errors.NewCCError("Failed to get userName parameter", 400)
 		}
 
+		policyVersion, ok := req["privacyPolicyVersion"].(string)
+		if !ok || policyVersion == "" {
+			return nil, errors.NewCCError("Failed to get privacyPolicyVersion parameter", 400)
+		}
+
+		existing, err := userByCPF(stub, cpf)
+		if err != nil {
+			return nil, err
+		}
+		if existing != "" {
+			return nil, errors.NewCCError("A user with this CPF already exists", 409)
+		}
+
+		// The user is bound to the identity creating it, other identities are bound by an admin
+		caller, err := utils.CallerIdentity(stub)
+		if err != nil {
+			return nil, err
+		}
+
 		signer := map[string]interface{}{
-			"@assetType": "user",
-			"cpf":        cpf,
-			"email":      email,
-			"name":       name,
-			"phone":      phone,
-			"userName":   userName,
+			"@assetType":   "user",
+			"id":           stub.Stub.GetTxID(),
+			"mspId":        caller.MspId,
+			"enrollmentId": caller.EnrollmentId,
+		}
+		if pin, _ := req["pinCertificate"].(bool); pin {
+			signer["certFingerprint"] = caller.CertFingerprint
 		}
 
 		newSigner, err := assets.NewAsset(signer)
@@ -93,6 +132,32 @@ var CreateSigner = tx.Transaction{
 			return nil, errors.WrapError(err, "failed to write user asset to the ledger")
 		}
 
+		personalData, err := assets.NewAsset(map[string]interface{}{
+			"@assetType": "personalData",
+			"user": map[string]interface{}{
+				"@assetType": "user",
+				"@key":       newSigner.Key(),
+			},
+			"cpf":      cpf,
+			"email":    email,
+			"name":     name,
+			"phone":    phone,
+			"userName": userName,
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to create personal data asset")
+		}
+
+		_, err = personalData.PutNew(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to write personal data to the ledger")
+		}
+
+		_, err = recordConsent(stub, newSigner.Key(), ConsentPersonalData, policyVersion)
+		if err != nil {
+			return nil, err
+		}
+
 		resBytes, e := json.Marshal(res)
 		if e != nil {
 			return nil, errors.WrapError(e, "failed to marshal response")
@@ -0,0 +1,25 @@
+package document
+
+import (
+	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/eventtypes"
+)
+
+// documentEvent builds the event data of a document as written to the ledger
+func documentEvent(document map[string]interface{}, signer string) eventtypes.DocumentEvent {
+	key, _ := document["@key"].(string)
+	name, _ := document["name"].(string)
+	required, _ := document["requiredSignatures"].([]interface{})
+	successful, _ := document["successfulSignatures"].([]interface{})
+
+	status, _ := datatypes.ParseStatus(document["status"])
+
+	return eventtypes.DocumentEvent{
+		Document:           key,
+		Name:               name,
+		Status:             float64(status),
+		Signer:             signer,
+		Signatures:         len(successful),
+		RequiredSignatures: len(required),
+	}
+}
@@ -0,0 +1,184 @@
+package document
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+var EraseUser = tx.Transaction{
+	Tag:         "eraseUser",
+	Label:       "Erase User",
+	Description: "Erases the personal data of a user and revokes their consents and delegations. The pseudonymous user asset is kept, so signatures and documents still reference it",
+	Method:      "POST",
+
+	Args: []tx.Argument{
+		{
+			Tag:      "user",
+			Label:    "User",
+			Required: true,
+			DataType: "->user",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		userKey, ok := req["user"].(assets.Key)
+		if !ok {
+			return nil, errors.NewCCError("Failed to get user parameter", http.StatusBadRequest)
+		}
+
+		// Only users request the erasure of their own data
+		err := utils.CheckCallerIsUser(stub, userKey)
+		if err != nil {
+			return nil, err
+		}
+
+		user, err := userKey.Get(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get user from the ledger")
+		}
+
+		if (*user)["company"] != nil {
+			return nil, errors.NewCCError("Companies have no personal data to erase", http.StatusBadRequest)
+		}
+
+		if erased, _ := (*user)["erased"].(bool); erased {
+			return nil, errors.NewCCError("User is already erased", http.StatusBadRequest)
+		}
+
+		now, err := txTime(stub)
+		if err != nil {
+			return nil, err
+		}
+
+		// Deleting the private data removes it from the peers state, only its hash stays on the chain.
+		// The private write sets that held it are purged once the collection blockToLive elapses
+		personalKey, err := personalDataKey(userKey.Key())
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get personal data key")
+		}
+		exists, err := personalKey.ExistsInLedger(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to check personal data")
+		}
+		if exists {
+			_, err = personalKey.Delete(stub)
+			if err != nil {
+				return nil, errors.WrapError(err, "Failed to delete personal data")
+			}
+		}
+
+		err = revokeUserConsents(stub, userKey.Key(), now)
+		if err != nil {
+			return nil, err
+		}
+
+		err = revokeUserDelegations(stub, userKey.Key(), now)
+		if err != nil {
+			return nil, err
+		}
+
+		// The bound identity may identify the user, only the public key is kept so the signatures
+		// can still be verified
+		erasedUser := map[string]interface{}{
+			"@assetType": "user",
+			"@key":       userKey.Key(),
+			"id":         (*user)["id"],
+			"erased":     true,
+			"erasedAt":   now.Format(time.RFC3339),
+		}
+		if publicKey, ok := (*user)["publicKey"]; ok {
+			erasedUser["publicKey"] = publicKey
+		}
+
+		erasedAsset, err := assets.NewAsset(erasedUser)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to create erased user asset")
+		}
+
+		res, err := erasedAsset.Put(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to write erased user to the ledger")
+		}
+
+		resBytes, nerr := json.Marshal(res)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		return resBytes, nil
+	},
+}
+
+// revokeUserConsents revokes the consents of the user still in force
+func revokeUserConsents(stub *sw.StubWrapper, userKey string, now time.Time) errors.ICCError {
+	query := map[string]interface{}{
+		"selector": map[string]interface{}{
+			"@assetType": "consent",
+			"user.@key":  userKey,
+			"revokedAt": map[string]interface{}{
+				"$exists": false,
+			},
+		},
+	}
+
+	response, err := assets.Search(stub, query, "", false)
+	if err != nil {
+		return errors.WrapErrorWithStatus(err, "error searching for consents", http.StatusInternalServerError)
+	}
+
+	for _, consent := range response.Result {
+		consentKey := assets.Key{
+			"@assetType": "consent",
+			"@key":       consent["@key"],
+		}
+		_, err = consentKey.Update(stub, map[string]interface{}{
+			"revokedAt": now.Format(time.RFC3339),
+		})
+		if err != nil {
+			return errors.WrapError(err, "Failed to revoke consent")
+		}
+	}
+
+	return nil
+}
+
+// revokeUserDelegations revokes the delegations granted by or to the user
+func revokeUserDelegations(stub *sw.StubWrapper, userKey string, now time.Time) errors.ICCError {
+	query := map[string]interface{}{
+		"selector": map[string]interface{}{
+			"@assetType": "delegation",
+			"revoked":    false,
+			"$or": []interface{}{
+				map[string]interface{}{"grantor.@key": userKey},
+				map[string]interface{}{"delegate.@key": userKey},
+			},
+		},
+	}
+
+	response, err := assets.Search(stub, query, "", false)
+	if err != nil {
+		return errors.WrapErrorWithStatus(err, "error searching for delegations", http.StatusInternalServerError)
+	}
+
+	for _, delegation := range response.Result {
+		delegationKey := assets.Key{
+			"@assetType": "delegation",
+			"@key":       delegation["@key"],
+		}
+		_, err = delegationKey.Update(stub, map[string]interface{}{
+			"revoked":   true,
+			"revokedAt": now.Format(time.RFC3339),
+		})
+		if err != nil {
+			return errors.WrapError(err, "Failed to revoke delegation")
+		}
+	}
+
+	return nil
+}
@@ -14,8 +14,9 @@ import (
 var ExpectedUserDoc = tx.Transaction{
 	Tag:         "expectedUserDoc",
 	Label:       "Expected User Document",
-	Description: "",
+	Description: "Returns the documents the signer is required to sign, with the signer rejection when there is one",
 	Method:      "GET",
+	ReadOnly:    true,
 
 	Args: []tx.Argument{
 		{
@@ -67,6 +68,12 @@ var ExpectedUserDoc = tx.Transaction{
 		if err != nil {
 			return nil, errors.WrapErrorWithStatus(err, "error searching for document", http.StatusInternalServerError)
 		}
+
+		for _, doc := range response.Result {
+			if rejection := signerRejection(doc, signerKey); rejection != nil {
+				doc["rejection"] = rejection
+			}
+		}
 		responseJSON, errr := json.Marshal(response)
 		if errr != nil {
 			return nil, errors.WrapErrorWithStatus(err, "error marshaling response", http.StatusInternalServerError)
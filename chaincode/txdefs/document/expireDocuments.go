@@ -0,0 +1,153 @@
+package document
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/eventtypes"
+)
+
+const defaultExpirationLimit = 100
+
+var ExpireDocuments = tx.Transaction{
+	Tag:         "expireDocuments",
+	Label:       "Expire Documents",
+	Description: "Sets the status of documents still collecting signatures whose timeout has passed to expired. At most 'limit' documents are expired per call and 'hasMore' tells if it should be called again",
+	Method:      "POST",
+
+	Args: []tx.Argument{
+		{
+			Tag:         "limit",
+			Label:       "Limit",
+			DataType:    "number",
+			Description: "Maximum number of documents expired by this call. Defaults to 100",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		limit := defaultExpirationLimit
+		if l, ok := req["limit"].(float64); ok {
+			if l < 1 {
+				return nil, errors.NewCCError("Parameter 'limit' must be greater than zero", http.StatusBadRequest)
+			}
+			limit = int(l)
+		}
+
+		now, err := txTime(stub)
+		if err != nil {
+			return nil, err
+		}
+
+		// Paginated queries are only allowed on read-only transactions, so the results are
+		// iterated until the limit is reached and the caller is told whether there are more.
+		// Timeouts are stored in UTC and compared as strings; isExpired still checks each result.
+		query, nerr := json.Marshal(map[string]interface{}{
+			"selector": map[string]interface{}{
+				"@assetType": "document",
+				"timeout": map[string]interface{}{
+					"$lt": now.UTC().Format(time.RFC3339),
+				},
+				"status": map[string]interface{}{
+					"$in": []float64{0, 4},
+				},
+			},
+			"use_index": []interface{}{"_design/indexTimeoutDoc", "indexTimeout"},
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "Failed to marshal query")
+		}
+
+		resultsIterator, err := stub.GetQueryResult(string(query))
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "error searching for documents", http.StatusInternalServerError)
+		}
+		defer resultsIterator.Close()
+
+		expiredKeys := make([]string, 0)
+		var expiredDocs []eventtypes.DocumentEvent
+		hasMore := false
+		for resultsIterator.HasNext() {
+			queryResponse, nerr := resultsIterator.Next()
+			if nerr != nil {
+				return nil, errors.WrapErrorWithStatus(nerr, "error iterating response", http.StatusInternalServerError)
+			}
+
+			var doc map[string]interface{}
+			nerr = json.Unmarshal(queryResponse.Value, &doc)
+			if nerr != nil {
+				return nil, errors.WrapErrorWithStatus(nerr, "failed to unmarshal document", http.StatusInternalServerError)
+			}
+
+			// Partially finalized documents halted by a rejection wait for the owner, not for signatures
+			if rejected, _ := doc["rejectedSignatures"].([]interface{}); len(rejected) > 0 || !isExpired(doc, now) {
+				continue
+			}
+
+			if len(expiredKeys) == limit {
+				hasMore = true
+				break
+			}
+
+			documentKey := assets.Key{
+				"@assetType": "document",
+				"@key":       queryResponse.Key,
+			}
+			updatedDocument, err := documentKey.Update(stub, map[string]interface{}{
+				"status": datatypes.StatusType(2),
+			})
+			if err != nil {
+				return nil, errors.WrapError(err, "Failed to update document asset in the ledger")
+			}
+
+			expiredKeys = append(expiredKeys, queryResponse.Key)
+			expiredDocs = append(expiredDocs, documentEvent(updatedDocument, ""))
+		}
+
+		if len(expiredDocs) > 0 {
+			err = eventtypes.Emit(stub, eventtypes.DocumentsExpired, expiredDocs)
+			if err != nil {
+				return nil, errors.WrapError(err, "Failed to emit document event")
+			}
+		}
+
+		responseJSON, nerr := json.Marshal(map[string]interface{}{
+			"expired": expiredKeys,
+			"hasMore": hasMore,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "Failed to marshal response to JSON format")
+		}
+
+		return responseJSON, nil
+	},
+}
+
+// isExpired tells whether the document timeout is before the given time
+func isExpired(doc map[string]interface{}, now time.Time) bool {
+	timeoutStr, ok := doc["timeout"].(string)
+	if !ok {
+		return false
+	}
+
+	timeout, err := time.Parse(time.RFC3339, timeoutStr)
+	if err != nil {
+		return false
+	}
+
+	return timeout.Before(now)
+}
+
+// txTime returns the transaction timestamp, which is the same on every peer
+func txTime(stub *sw.StubWrapper) (time.Time, errors.ICCError) {
+	txTimestamp, err := stub.Stub.GetTxTimestamp()
+	if err != nil {
+		return time.Time{}, errors.WrapError(err, "Failed to get transaction timestamp")
+	}
+
+	return time.Unix(txTimestamp.Seconds, int64(txTimestamp.Nanos)).UTC(), nil
+}
@@ -0,0 +1,159 @@
+package document
+
+import (
+	"encoding/json"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+// DocumentVersion is a version of a document with the signatures made on it.
+// Carried over signatures were made on a previous version of the chain.
+type DocumentVersion struct {
+	Version               float64                  `json:"version"`
+	Document              map[string]interface{}   `json:"document"`
+	Signatures            []map[string]interface{} `json:"signatures"`
+	CarriedOverSignatures []string                 `json:"carriedOverSignatures"`
+}
+
+var GetDocumentVersions = tx.Transaction{
+	Tag:         "getDocumentVersions",
+	Label:       "Get Document Versions",
+	Description: "Returns every version of a document, from the first to the latest, with the signatures made on each version",
+	Method:      "GET",
+	ReadOnly:    true,
+
+	Args: []tx.Argument{
+		{
+			Tag:         "document",
+			Label:       "Document",
+			Required:    true,
+			DataType:    "->document",
+			Description: "Any version of the document",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		documentKey, ok := req["document"].(assets.Key)
+		if !ok {
+			return nil, errors.NewCCError("Failed to get document parameter", 400)
+		}
+
+		document, err := documentKey.Get(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get document from the ledger")
+		}
+
+		// Walk back to the first version, then forward to the latest one
+		visited := map[string]bool{document.Key(): true}
+		for {
+			previous := utils.RefKeys([]interface{}{(*document)["previousVersion"]})
+			if len(previous) == 0 || visited[previous[0]] {
+				break
+			}
+			document, err = getDocumentByKey(stub, previous[0])
+			if err != nil {
+				return nil, err
+			}
+			visited[previous[0]] = true
+		}
+
+		var versions []DocumentVersion
+		visited = make(map[string]bool)
+		for document != nil && !visited[document.Key()] {
+			visited[document.Key()] = true
+
+			version, err := documentVersion(stub, *document)
+			if err != nil {
+				return nil, err
+			}
+			versions = append(versions, *version)
+
+			next := utils.RefKeys([]interface{}{(*document)["supersededBy"]})
+			if len(next) == 0 {
+				break
+			}
+			document, err = getDocumentByKey(stub, next[0])
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		responseJSON, nerr := json.Marshal(versions)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "Failed to marshal response")
+		}
+
+		return responseJSON, nil
+	},
+}
+
+func getDocumentByKey(stub *sw.StubWrapper, key string) (*assets.Asset, errors.ICCError) {
+	documentKey := assets.Key{"@assetType": "document", "@key": key}
+	document, err := documentKey.Get(stub)
+	if err != nil {
+		return nil, errors.WrapError(err, "Failed to get document version from the ledger")
+	}
+	return document, nil
+}
+
+// documentVersion gathers the signature records made on the document
+func documentVersion(stub *sw.StubWrapper, document map[string]interface{}) (*DocumentVersion, errors.ICCError) {
+	version := 1.0
+	if v, ok := document["version"].(float64); ok {
+		version = v
+	}
+
+	carriedOver := make(map[string]bool)
+	carriedOverKeys := utils.RefKeys(document["carriedOverSignatures"])
+	for _, key := range carriedOverKeys {
+		carriedOver[key] = true
+	}
+
+	signatures := make([]map[string]interface{}, 0)
+	for _, signer := range utils.RefKeys(document["successfulSignatures"]) {
+		signatureKey, err := assets.NewKey(map[string]interface{}{
+			"@assetType": "signature",
+			"document":   map[string]interface{}{"@assetType": "document", "@key": document["@key"]},
+			"signer":     map[string]interface{}{"@assetType": "user", "@key": signer},
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to generate signature key")
+		}
+
+		exists, err := signatureKey.ExistsInLedger(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to check signature")
+		}
+		if !exists && carriedOver[signer] {
+			// Older amendments counted carried over signers as signed without a record on this version
+			continue
+		}
+		if !exists {
+			// Signatures recorded before verification was required have no signature record
+			signatures = append(signatures, map[string]interface{}{
+				"signer": map[string]interface{}{"@assetType": "user", "@key": signer},
+			})
+			continue
+		}
+
+		signature, err := signatureKey.Get(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get signature")
+		}
+		signatures = append(signatures, *signature)
+	}
+
+	if carriedOverKeys == nil {
+		carriedOverKeys = []string{}
+	}
+
+	return &DocumentVersion{
+		Version:               version,
+		Document:              document,
+		Signatures:            signatures,
+		CarriedOverSignatures: carriedOverKeys,
+	}, nil
+}
@@ -0,0 +1,62 @@
+package document
+
+import (
+	"encoding/json"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+var GetExpectedSigners = tx.Transaction{
+	Tag:         "getExpectedSigners",
+	Label:       "Get Expected Signers",
+	Description: "Returns the signers currently expected to sign a document and the step of its signing order",
+	Method:      "GET",
+	ReadOnly:    true,
+
+	Args: []tx.Argument{
+		{
+			Tag:      "document",
+			Label:    "Document",
+			Required: true,
+			DataType: "->document",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		documentKey, ok := req["document"].(assets.Key)
+		if !ok {
+			return nil, errors.NewCCError("Failed to get document parameter", 400)
+		}
+
+		document, err := documentKey.Get(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get document from the ledger")
+		}
+
+		expected, step, err := expectedSigners(*document)
+		if err != nil {
+			return nil, err
+		}
+
+		signers := make([]map[string]interface{}, 0, len(expected))
+		for _, key := range expected {
+			signers = append(signers, map[string]interface{}{
+				"@assetType": "user",
+				"@key":       key,
+			})
+		}
+
+		responseJSON, nerr := json.Marshal(map[string]interface{}{
+			"document":        documentKey.Key(),
+			"step":            step,
+			"expectedSigners": signers,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "Failed to marshal response")
+		}
+
+		return responseJSON, nil
+	},
+}
@@ -3,7 +3,6 @@ package document
 import (
 	"encoding/json"
 	"net/http"
-	"time"
 
 	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/errors"
@@ -16,6 +15,7 @@ var GetExpiredDoc = tx.Transaction{
 	Label:       "Get Expired Documents",
 	Description: "Return Expired documents",
 	Method:      "GET",
+	ReadOnly:    true,
 
 	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
 		query := map[string]interface{}{
@@ -34,21 +34,14 @@ var GetExpiredDoc = tx.Transaction{
 			return []byte("[]"), nil
 		}
 
-		currentTime := time.Now().UTC()
+		currentTime, err := txTime(stub)
+		if err != nil {
+			return nil, err
+		}
 
 		var filteredDocs []map[string]interface{}
 		for _, doc := range response.Result {
-			timeoutStr, ok := doc["timeout"].(string)
-			if !ok {
-				continue
-			}
-
-			timeout, err := time.Parse(time.RFC3339, timeoutStr)
-			if err != nil {
-				continue
-			}
-
-			if timeout.Before(currentTime) {
+			if isExpired(doc, currentTime) {
 				filteredDocs = append(filteredDocs, doc)
 			}
 		}
@@ -0,0 +1,58 @@
+package document
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+var GetPersonalData = tx.Transaction{
+	Tag:         "getPersonalData",
+	Label:       "Get Personal Data",
+	Description: "Returns the personal data of the calling user. Only peers of the personalData collection members can answer",
+	Method:      "GET",
+	ReadOnly:    true,
+
+	Args: []tx.Argument{
+		{
+			Tag:      "user",
+			Label:    "User",
+			Required: true,
+			DataType: "->user",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		userKey, ok := req["user"].(assets.Key)
+		if !ok {
+			return nil, errors.NewCCError("Failed to get user parameter", http.StatusBadRequest)
+		}
+
+		// Users only read their own personal data
+		err := utils.CheckCallerIsUser(stub, userKey)
+		if err != nil {
+			return nil, err
+		}
+
+		personalKey, err := personalDataKey(userKey.Key())
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get personal data key")
+		}
+
+		personalData, err := personalKey.Get(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get personal data")
+		}
+
+		resBytes, nerr := json.Marshal(personalData)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		return resBytes, nil
+	},
+}
@@ -2,6 +2,7 @@ package document
 
 import (
 	"encoding/json"
+	"net/http"
 
 	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/errors"
@@ -12,8 +13,9 @@ import (
 var GetUserKey = tx.Transaction{
 	Tag:         "getUserKey",
 	Label:       "Get User Key",
-	Description: "Retrieves the key of the User by CPF",
+	Description: "Retrieves the key of the User by CPF. Only peers of the personalData collection members can answer",
 	Method:      "GET",
+	ReadOnly:    true,
 
 	Args: []tx.Argument{
 		{
@@ -24,21 +26,24 @@ var GetUserKey = tx.Transaction{
 		},
 	},
 	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
-		cpf := req["cpf"]
+		cpf, _ := req["cpf"].(string)
 
-		fields := map[string]interface{}{
-			"@assetType": "user",
-			"cpf":        cpf,
+		userKey, err := userByCPF(stub, cpf)
+		if err != nil {
+			return nil, err
+		}
+		if userKey == "" {
+			return nil, errors.NewCCError("User not found", http.StatusNotFound)
 		}
 
-		key, err := assets.NewKey(fields)
-		if err != nil {
-			return nil, errors.WrapError(err, "failed to generate key")
+		key := assets.Key{
+			"@assetType": "user",
+			"@key":       userKey,
 		}
 
 		keyJSON, er := json.Marshal(key)
 		if er != nil {
-			return nil, errors.WrapError(err, "failed to marshal key to JSON")
+			return nil, errors.WrapError(er, "failed to marshal key to JSON")
 		}
 
 		return keyJSON, nil
@@ -0,0 +1,78 @@
+package document
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+var GrantConsent = tx.Transaction{
+	Tag:         "grantConsent",
+	Label:       "Grant Consent",
+	Description: "Records the consent of a user to the processing of their personal data for a purpose. Renewing the personalData consent also keeps the personal data from expiring",
+	Method:      "POST",
+
+	Args: []tx.Argument{
+		{
+			Tag:      "user",
+			Label:    "User",
+			Required: true,
+			DataType: "->user",
+		},
+		{
+			Tag:      "purpose",
+			Label:    "Purpose",
+			Required: true,
+			DataType: "string",
+		},
+		{
+			Tag:         "policyVersion",
+			Label:       "Policy Version",
+			Required:    true,
+			DataType:    "string",
+			Description: "Version of the privacy policy the user agreed to",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		userKey, ok := req["user"].(assets.Key)
+		if !ok {
+			return nil, errors.NewCCError("Failed to get user parameter", http.StatusBadRequest)
+		}
+
+		// Only users consent for themselves
+		err := utils.CheckCallerIsUser(stub, userKey)
+		if err != nil {
+			return nil, err
+		}
+
+		purpose, _ := req["purpose"].(string)
+		policyVersion, _ := req["policyVersion"].(string)
+		if purpose == "" || policyVersion == "" {
+			return nil, errors.NewCCError("Purpose and policy version must not be empty", http.StatusBadRequest)
+		}
+
+		res, err := recordConsent(stub, userKey.Key(), purpose, policyVersion)
+		if err != nil {
+			return nil, err
+		}
+
+		if purpose == ConsentPersonalData {
+			err = renewPersonalData(stub, userKey.Key())
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		resBytes, nerr := json.Marshal(res)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		return resBytes, nil
+	},
+}
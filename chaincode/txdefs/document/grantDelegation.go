@@ -0,0 +1,157 @@
+package document
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+// Scopes of a delegation
+const (
+	DelegationDocument = "document"
+	DelegationOwner    = "owner"
+	DelegationAll      = "all"
+)
+
+var GrantDelegation = tx.Transaction{
+	Tag:         "grantDelegation",
+	Label:       "Grant Delegation",
+	Description: "Lets a delegate sign documents on behalf of the grantor during the validity period",
+	Method:      "POST",
+
+	Args: []tx.Argument{
+		{
+			Tag:      "grantor",
+			Label:    "Grantor",
+			Required: true,
+			DataType: "->user",
+		},
+		{
+			Tag:      "delegate",
+			Label:    "Delegate",
+			Required: true,
+			DataType: "->user",
+		},
+		{
+			Tag:         "scope",
+			Label:       "Scope",
+			Required:    true,
+			DataType:    "string",
+			Description: "document (a single document), owner (documents of one owner) or all",
+		},
+		{
+			Tag:         "document",
+			Label:       "Document",
+			DataType:    "->document",
+			Description: "Required when the scope is document",
+		},
+		{
+			Tag:         "documentOwner",
+			Label:       "Document Owner",
+			DataType:    "->user",
+			Description: "Required when the scope is owner",
+		},
+		{
+			Tag:         "validFrom",
+			Label:       "Valid From",
+			DataType:    "datetime",
+			Description: "Defaults to the transaction timestamp",
+		},
+		{
+			Tag:      "validUntil",
+			Label:    "Valid Until",
+			Required: true,
+			DataType: "datetime",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		grantor, ok := req["grantor"].(assets.Key)
+		if !ok {
+			return nil, errors.NewCCError("Failed to get grantor parameter", 400)
+		}
+
+		delegate, ok := req["delegate"].(assets.Key)
+		if !ok {
+			return nil, errors.NewCCError("Failed to get delegate parameter", 400)
+		}
+
+		if grantor.Key() == delegate.Key() {
+			return nil, errors.NewCCError("Users cannot delegate to themselves", 400)
+		}
+
+		// Only the grantor can delegate their own signature
+		err := utils.CheckCallerIsUser(stub, grantor)
+		if err != nil {
+			return nil, err
+		}
+
+		now, err := txTime(stub)
+		if err != nil {
+			return nil, err
+		}
+
+		validFrom := now
+		if from, ok := req["validFrom"].(time.Time); ok {
+			validFrom = from
+		}
+		validUntil, ok := req["validUntil"].(time.Time)
+		if !ok {
+			return nil, errors.NewCCError("Failed to get validUntil parameter", 400)
+		}
+		if !validUntil.After(validFrom) {
+			return nil, errors.NewCCError("validUntil must be after validFrom", 400)
+		}
+
+		scope, _ := req["scope"].(string)
+		delegation := map[string]interface{}{
+			"@assetType": "delegation",
+			"id":         stub.Stub.GetTxID(),
+			"grantor":    grantor,
+			"delegate":   delegate,
+			"scope":      scope,
+			"validFrom":  validFrom.Format(time.RFC3339),
+			"validUntil": validUntil.Format(time.RFC3339),
+			"revoked":    false,
+		}
+
+		switch scope {
+		case DelegationDocument:
+			document, ok := req["document"].(assets.Key)
+			if !ok {
+				return nil, errors.NewCCError("A document must be given when the scope is document", 400)
+			}
+			delegation["document"] = document
+		case DelegationOwner:
+			owner, ok := req["documentOwner"].(assets.Key)
+			if !ok {
+				return nil, errors.NewCCError("A document owner must be given when the scope is owner", 400)
+			}
+			delegation["documentOwner"] = owner
+		case DelegationAll:
+		default:
+			return nil, errors.NewCCError("Scope must be document, owner or all", 400)
+		}
+
+		delegationAsset, err := assets.NewAsset(delegation)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to create delegation asset")
+		}
+
+		res, err := delegationAsset.PutNew(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to write delegation to the ledger")
+		}
+
+		resBytes, nerr := json.Marshal(res)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		return resBytes, nil
+	},
+}
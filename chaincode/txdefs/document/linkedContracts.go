@@ -0,0 +1,96 @@
+package document
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+// updateLinkedContracts updates the contracts waiting for the document to be signed. Contracts
+// follow the document to its amended version, given as newDocument, and become active with the
+// transaction timestamp as signature date once the document they reference is finalized.
+func updateLinkedContracts(stub *sw.StubWrapper, document string, newDocument string, finalized bool) errors.ICCError {
+	if newDocument == "" && !finalized {
+		return nil
+	}
+
+	query := map[string]interface{}{
+		"selector": map[string]interface{}{
+			"@assetType":    "autoExecutableContract",
+			"document.@key": document,
+			"status":        datatypes.ContractPendingSignature,
+		},
+	}
+
+	response, err := assets.Search(stub, query, "", true)
+	if err != nil {
+		return errors.WrapErrorWithStatus(err, "error searching for contracts linked to the document", http.StatusInternalServerError)
+	}
+
+	if len(response.Result) == 0 {
+		return nil
+	}
+
+	signedAt, err := txTime(stub)
+	if err != nil {
+		return err
+	}
+
+	// Contracts only follow an amended version that still requires every party to sign
+	required := make(map[string]bool)
+	if newDocument != "" {
+		newKey := assets.Key{"@assetType": "document", "@key": newDocument}
+		amended, err := newKey.Get(stub)
+		if err != nil {
+			return errors.WrapError(err, "Failed to get amended document")
+		}
+		for _, key := range utils.RefKeys((*amended)["requiredSignatures"]) {
+			required[key] = true
+		}
+	}
+
+	for _, contract := range response.Result {
+		update := make(map[string]interface{})
+		if newDocument != "" {
+			participants, _ := contract["participants"].([]interface{})
+			for _, key := range utils.RefKeys(append([]interface{}{contract["owner"]}, participants...)) {
+				if !required[key] {
+					return errors.NewCCError(fmt.Sprintf("Party %s of contract %s linked to the document must remain a required signer", key, contract["@key"]), http.StatusBadRequest)
+				}
+			}
+
+			update["document"] = map[string]interface{}{
+				"@assetType": "document",
+				"@key":       newDocument,
+			}
+		}
+		if finalized {
+			dates, ok := contract["dates"].(map[string]interface{})
+			if !ok {
+				dates = make(map[string]interface{})
+			}
+			dates["signature"] = signedAt.Format(time.RFC3339)
+
+			update["status"] = datatypes.ContractActive
+			update["signatureDate"] = signedAt.Format(time.RFC3339)
+			update["dates"] = dates
+		}
+
+		contractKey := assets.Key{
+			"@assetType": "autoExecutableContract",
+			"@key":       contract["@key"],
+		}
+		_, err = contractKey.Update(stub, update)
+		if err != nil {
+			return errors.WrapError(err, "Failed to update contract linked to the document")
+		}
+	}
+
+	return nil
+}
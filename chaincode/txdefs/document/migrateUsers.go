@@ -0,0 +1,306 @@
+package document
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+var MigrateUsers = tx.Transaction{
+	Tag:         "migrateUsers",
+	Label:       "Migrate Users",
+	Description: "Moves the CPF, email, name, phone and user name of users created before they were private to the personalData collection. Users keyed by their CPF get a new random key: the documents, signatures, contracts and other assets referencing them are rewritten to it and the legacy key is kept only on the personalData collection. Users not migrated yet cannot be read, so an org admin runs it right after the upgrade until no user remains. Values already written remain in the ledger history",
+	Method:      "POST",
+
+	Args: []tx.Argument{
+		{
+			Tag:         "limit",
+			Label:       "Limit",
+			DataType:    "number",
+			Description: "Maximum number of users migrated by the transaction, defaults to 100",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		caller, err := utils.CallerIdentity(stub)
+		if err != nil {
+			return nil, err
+		}
+
+		err = utils.CheckCallerIsAdmin(stub, caller.MspId)
+		if err != nil {
+			return nil, err
+		}
+
+		limit := 100
+		if l, ok := req["limit"].(float64); ok {
+			if l < 1 {
+				return nil, errors.NewCCError("Parameter 'limit' must be positive", http.StatusBadRequest)
+			}
+			limit = int(l)
+		}
+
+		// Legacy users are keyed by their CPF and have no id, users created before the user
+		// name was private still have it on the public asset
+		query := map[string]interface{}{
+			"selector": map[string]interface{}{
+				"@assetType": "user",
+				"$or": []interface{}{
+					map[string]interface{}{"id": map[string]interface{}{"$exists": false}},
+					map[string]interface{}{"userName": map[string]interface{}{"$exists": true}},
+				},
+			},
+		}
+
+		// Results are not resolved, as legacy users fail the validation of the current user type
+		response, err := assets.Search(stub, query, "", false)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "error searching for users to migrate", http.StatusInternalServerError)
+		}
+
+		migrated := 0
+		for _, user := range response.Result {
+			if migrated == limit {
+				break
+			}
+
+			err = migrateUser(stub, user, fmt.Sprintf("%s-%d", stub.Stub.GetTxID(), migrated))
+			if err != nil {
+				return nil, err
+			}
+			migrated++
+		}
+
+		resBytes, nerr := json.Marshal(map[string]interface{}{
+			"migrated":  migrated,
+			"remaining": len(response.Result) - migrated,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		return resBytes, nil
+	},
+}
+
+// migrateUser moves the personal data left on the stored user to its personalData entry and
+// rewrites the user with the remaining public fields. Legacy users, keyed by their CPF, get the
+// new id: the assets referencing them are rewritten to the new key, the legacy key is kept only
+// on the personalData entry and the legacy user is deleted.
+func migrateUser(stub *sw.StubWrapper, user map[string]interface{}, newId string) errors.ICCError {
+	key, _ := user["@key"].(string)
+	if key == "" {
+		return errors.NewCCError("User has no key", http.StatusInternalServerError)
+	}
+	rekey := user["id"] == nil
+
+	public := map[string]interface{}{
+		"@assetType": "user",
+	}
+	for prop, value := range user {
+		if strings.HasPrefix(prop, "@") || isPersonalDataField(prop) {
+			continue
+		}
+		public[prop] = value
+	}
+	if rekey {
+		public["id"] = newId
+	} else {
+		public["@key"] = key
+	}
+	// Rewriting the user records the migrating org as its last writer, so the org that wrote
+	// the legacy user is kept to decide who can bind it to an identity
+	if public["mspId"] == nil && public["homeMspId"] == nil {
+		if home, ok := user["@lastTouchBy"].(string); ok && home != "" {
+			public["homeMspId"] = home
+		}
+	}
+
+	publicAsset, err := assets.NewAsset(public)
+	if err != nil {
+		return errors.WrapError(err, "Failed to create user asset")
+	}
+
+	_, err = publicAsset.Put(stub)
+	if err != nil {
+		return errors.WrapError(err, "Failed to write user to the ledger")
+	}
+
+	// Personal data written before the migration is kept, the values left on the user take precedence
+	personal := map[string]interface{}{
+		"@assetType": "personalData",
+	}
+	legacyPersonalKey, err := personalDataKey(key)
+	if err != nil {
+		return errors.WrapError(err, "Failed to get personal data key")
+	}
+	exists, err := legacyPersonalKey.ExistsInLedger(stub)
+	if err != nil {
+		return errors.WrapError(err, "Failed to check personal data")
+	}
+	if exists {
+		current, err := legacyPersonalKey.Get(stub)
+		if err != nil {
+			return errors.WrapError(err, "Failed to get personal data")
+		}
+		for prop, value := range *current {
+			if !strings.HasPrefix(prop, "@") {
+				personal[prop] = value
+			}
+		}
+	}
+	for _, field := range personalDataFields {
+		if value, ok := user[field].(string); ok && value != "" {
+			personal[field] = value
+		}
+	}
+	personal["user"] = map[string]interface{}{
+		"@assetType": "user",
+		"@key":       publicAsset.Key(),
+	}
+	if rekey {
+		personal["legacyKey"] = key
+	}
+
+	personalAsset, err := assets.NewAsset(personal)
+	if err != nil {
+		return errors.WrapError(err, "Failed to create personal data asset")
+	}
+
+	_, err = personalAsset.Put(stub)
+	if err != nil {
+		return errors.WrapError(err, "Failed to write personal data to the ledger")
+	}
+
+	if !rekey {
+		return nil
+	}
+
+	if exists {
+		_, err = legacyPersonalKey.Delete(stub)
+		if err != nil {
+			return errors.WrapError(err, "Failed to delete legacy personal data")
+		}
+	}
+
+	err = rekeyUserReferrers(stub, key, publicAsset.Key())
+	if err != nil {
+		return err
+	}
+
+	// The legacy user does not pass the validation of the current user type, so it is deleted as stored
+	legacyUser := assets.Asset(user)
+	_, err = legacyUser.Delete(stub)
+	if err != nil {
+		return errors.WrapError(err, "Failed to delete legacy user")
+	}
+
+	return nil
+}
+
+// rekeyUserReferrers points every asset referencing the user oldKey to newKey. Assets whose key
+// depends on the user, such as signatures, are written under their new key and the old one is deleted.
+func rekeyUserReferrers(stub *sw.StubWrapper, oldKey, newKey string) errors.ICCError {
+	oldUserKey := assets.Key{"@assetType": "user", "@key": oldKey}
+	referrers, err := oldUserKey.Referrers(stub)
+	if err != nil {
+		return errors.WrapError(err, "Failed to get assets referencing the user")
+	}
+
+	for _, referrerKey := range referrers {
+		referrer, err := referrerKey.Get(stub)
+		if err != nil {
+			return errors.WrapError(err, fmt.Sprintf("Failed to get %s", referrerKey.Key()))
+		}
+
+		rewritten := map[string]interface{}{
+			"@assetType": referrerKey.TypeTag(),
+		}
+		changes := make(map[string]interface{})
+		for prop, value := range *referrer {
+			if strings.HasPrefix(prop, "@") {
+				continue
+			}
+			replaced, ok := replaceUserRef(value, oldKey, newKey)
+			rewritten[prop] = replaced
+			if ok {
+				changes[prop] = replaced
+			}
+		}
+
+		rewrittenAsset, err := assets.NewAsset(rewritten)
+		if err != nil {
+			return errors.WrapError(err, fmt.Sprintf("Failed to rewrite %s", referrerKey.Key()))
+		}
+
+		if rewrittenAsset.Key() == referrerKey.Key() {
+			_, err = referrerKey.Update(stub, changes)
+			if err != nil {
+				return errors.WrapError(err, fmt.Sprintf("Failed to update %s", referrerKey.Key()))
+			}
+			continue
+		}
+
+		_, err = rewrittenAsset.PutNew(stub)
+		if err != nil {
+			return errors.WrapError(err, fmt.Sprintf("Failed to write %s under its new key", referrerKey.Key()))
+		}
+		_, err = referrerKey.Delete(stub)
+		if err != nil {
+			return errors.WrapError(err, fmt.Sprintf("Failed to delete %s", referrerKey.Key()))
+		}
+	}
+
+	return nil
+}
+
+// replaceUserRef returns the value with the references to the user oldKey pointing to newKey,
+// and whether any reference was replaced
+func replaceUserRef(value interface{}, oldKey, newKey string) (interface{}, bool) {
+	switch v := value.(type) {
+	case assets.Key:
+		if v.Key() == oldKey {
+			return assets.Key{"@assetType": "user", "@key": newKey}, true
+		}
+		return v, false
+	case map[string]interface{}:
+		if key, _ := v["@key"].(string); key == oldKey {
+			return map[string]interface{}{"@assetType": "user", "@key": newKey}, true
+		}
+		result := make(map[string]interface{}, len(v))
+		replaced := false
+		for k, nested := range v {
+			r, ok := replaceUserRef(nested, oldKey, newKey)
+			result[k] = r
+			replaced = replaced || ok
+		}
+		return result, replaced
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		replaced := false
+		for i, nested := range v {
+			r, ok := replaceUserRef(nested, oldKey, newKey)
+			result[i] = r
+			replaced = replaced || ok
+		}
+		return result, replaced
+	default:
+		return value, false
+	}
+}
+
+// isPersonalDataField tells whether the user field is kept on the personalData collection
+func isPersonalDataField(field string) bool {
+	for _, f := range personalDataFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
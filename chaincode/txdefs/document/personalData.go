@@ -0,0 +1,117 @@
+package document
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+)
+
+// ConsentPersonalData is the purpose of the consent recorded when a user is created
+const ConsentPersonalData = "personalData"
+
+// personalDataFields are the user fields kept on the private personalData collection
+var personalDataFields = []string{"cpf", "email", "name", "phone", "userName"}
+
+// personalDataKey returns the key of the personal data of the user
+func personalDataKey(userKey string) (assets.Key, errors.ICCError) {
+	return assets.NewKey(map[string]interface{}{
+		"@assetType": "personalData",
+		"user": map[string]interface{}{
+			"@assetType": "user",
+			"@key":       userKey,
+		},
+	})
+}
+
+// userByCPF returns the key of the user with the given CPF, or an empty string when there is none
+func userByCPF(stub *sw.StubWrapper, cpf string) (string, errors.ICCError) {
+	query := map[string]interface{}{
+		"selector": map[string]interface{}{
+			"@assetType": "personalData",
+			"cpf":        cpf,
+		},
+	}
+
+	response, err := assets.Search(stub, query, "personalData", false)
+	if err != nil {
+		return "", errors.WrapErrorWithStatus(err, "error searching for personal data", http.StatusInternalServerError)
+	}
+
+	for _, data := range response.Result {
+		if user, ok := data["user"].(map[string]interface{}); ok {
+			key, _ := user["@key"].(string)
+			return key, nil
+		}
+	}
+
+	return "", nil
+}
+
+// renewPersonalData rewrites the personal data of the user, which restarts the blockToLive of the
+// personalData collection so that the data of active users does not expire
+func renewPersonalData(stub *sw.StubWrapper, userKey string) errors.ICCError {
+	personalKey, err := personalDataKey(userKey)
+	if err != nil {
+		return errors.WrapError(err, "Failed to get personal data key")
+	}
+
+	personalData, err := personalKey.Get(stub)
+	if err != nil {
+		return errors.WrapError(err, "Failed to get personal data")
+	}
+
+	renewed := map[string]interface{}{
+		"@assetType": "personalData",
+	}
+	for prop, value := range *personalData {
+		if !strings.HasPrefix(prop, "@") {
+			renewed[prop] = value
+		}
+	}
+
+	renewedAsset, err := assets.NewAsset(renewed)
+	if err != nil {
+		return errors.WrapError(err, "Failed to create personal data asset")
+	}
+
+	_, err = renewedAsset.Put(stub)
+	if err != nil {
+		return errors.WrapError(err, "Failed to write personal data to the ledger")
+	}
+
+	return nil
+}
+
+// recordConsent writes the consent of the user to the processing of their personal data for the purpose
+func recordConsent(stub *sw.StubWrapper, userKey, purpose, policyVersion string) (map[string]interface{}, errors.ICCError) {
+	now, err := txTime(stub)
+	if err != nil {
+		return nil, err
+	}
+
+	consentAsset, err := assets.NewAsset(map[string]interface{}{
+		"@assetType": "consent",
+		"id":         stub.Stub.GetTxID(),
+		"user": map[string]interface{}{
+			"@assetType": "user",
+			"@key":       userKey,
+		},
+		"purpose":       purpose,
+		"policyVersion": policyVersion,
+		"grantedAt":     now.Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, errors.WrapError(err, "Failed to create consent asset")
+	}
+
+	res, err := consentAsset.PutNew(stub)
+	if err != nil {
+		return nil, errors.WrapError(err, "Failed to write consent to the ledger")
+	}
+
+	return res, nil
+}
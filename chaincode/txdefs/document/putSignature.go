@@ -1,13 +1,18 @@
 package document
 
 import (
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"time"
 
 	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/errors"
 	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
 	tx "github.com/hyperledger-labs/cc-tools/transactions"
 	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/eventtypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
 )
 
 var PutSignature = tx.Transaction{
@@ -29,6 +34,25 @@ var PutSignature = tx.Transaction{
 			Required: true,
 			DataType: "@asset",
 		},
+		{
+			Tag:         "signature",
+			Label:       "Signature",
+			Required:    true,
+			DataType:    "string",
+			Description: "Base64 encoded signature over the document originalHash made with the key registered by the user",
+		},
+		{
+			Tag:         "algorithm",
+			Label:       "Algorithm",
+			DataType:    "string",
+			Description: "ecdsa-sha256, ed25519, rsa-pkcs1v15-sha256 or rsa-pss-sha256. Inferred from the key when not provided",
+		},
+		{
+			Tag:         "delegation",
+			Label:       "Delegation",
+			DataType:    "->delegation",
+			Description: "Delegation of the user when the caller signs on their behalf. The signature is made with the delegate key",
+		},
 	},
 
 	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
@@ -84,11 +108,20 @@ var PutSignature = tx.Transaction{
 				rejectedSignatures = []interface{}{}
 			}
 
-			status := document.GetProp("status").(datatypes.StatusType)
-			if status != 0 {
+			if !acceptsSignatures(*document) {
 				return nil, errors.NewCCError("Document is not waiting for signatures", 400)
 			}
 
+			if document.GetProp("supersededBy") != nil {
+				return nil, errors.NewCCError("Document was amended, sign its latest version", 400)
+			}
+
+			// Only the signer themself or their delegate can sign
+			delegation, err := checkSigningAuthority(stub, req, signerAsset.Key(), documentKey.Key(), document.GetProp("owner"))
+			if err != nil {
+				return nil, err
+			}
+
 			signerKey := map[string]interface{}{
 				"@assetType": signerAsset["@assetType"],
 				"@key":       signerAsset["@key"],
@@ -118,36 +151,102 @@ var PutSignature = tx.Transaction{
 				}
 			}
 
-			if !isSignerSuccessful {
-				successfulSignatures = append(successfulSignatures, signerKey)
+			if isSignerSuccessful {
+				return nil, errors.NewCCError("Signer already signed this document", 400)
+			}
+
+			err = checkSignerTurn(*document, signerKey["@key"].(string))
+			if err != nil {
+				return nil, err
 			}
+			successfulSignatures = append(successfulSignatures, signerKey)
 
-			isLastSignature := len(successfulSignatures) == len(requiredSignatures)
+			(*document)["successfulSignatures"] = successfulSignatures
+			status, err := signatureStatus(*document)
+			if err != nil {
+				return nil, err
+			}
+			isLastSignature := status == 3
 
 			fields := map[string]interface{}{
 				"successfulSignatures": successfulSignatures,
+				"status":               status,
 			}
 
 			if len(rejectedSignatures) > 0 {
 				fields["rejectedSignatures"] = rejectedSignatures
 			}
 
-			if isLastSignature {
-				fields["status"] = 3
-			}
-
 			updatedDocument, err := documentKey.Update(stub, fields)
 			if err != nil {
 				return nil, errors.WrapError(err, "Failed to update document")
 			}
 
+			err = putDocumentSignature(stub, updatedDocument, signerKey, delegation, req)
+			if err != nil {
+				return nil, err
+			}
+
+			// Contracts linked to the document become binding once it is fully signed
+			if isLastSignature {
+				err = updateLinkedContracts(stub, documentKey.Key(), "", true)
+				if err != nil {
+					return nil, err
+				}
+			}
+
 			response = map[string]interface{}{
 				"document": updatedDocument,
 			}
 
+			event := eventtypes.SignatureAdded
+			if isLastSignature {
+				event = eventtypes.DocumentSigned
+			}
+			err = eventtypes.Emit(stub, event, signatureEvent(updatedDocument, signerKey["@key"].(string), delegation))
+			if err != nil {
+				return nil, errors.WrapError(err, "failed to emit signature event")
+			}
+
 		} else {
+			// Only the signer themself or their delegate can sign
+			delegation, err := checkSigningAuthority(stub, req, signerAsset.Key(), documentKey.Key(), documentAsset["owner"])
+			if err != nil {
+				return nil, err
+			}
+
+			documentAsset["status"] = datatypes.StatusType(0)
+
+			required, _ := documentAsset["requiredSignatures"].([]interface{})
+			if signingOrder, ok := documentAsset["signingOrder"].([]interface{}); ok && len(signingOrder) > 0 {
+				order, err := parseSigningOrder(signingOrder)
+				if err != nil {
+					return nil, err
+				}
+				err = checkSigningOrder(order, required)
+				if err != nil {
+					return nil, err
+				}
+			}
+			quorums, err := parseQuorums(documentAsset["quorums"])
+			if err != nil {
+				return nil, err
+			}
+			err = checkQuorums(quorums, required)
+			if err != nil {
+				return nil, err
+			}
+
+			signer, _ := signerAsset["@key"].(string)
+			err = checkSignerTurn(documentAsset, signer)
+			if err != nil {
+				return nil, err
+			}
 			documentAsset["successfulSignatures"] = []interface{}{signerAsset}
-			documentAsset["status"] = 0
+			documentAsset["status"], err = signatureStatus(documentAsset)
+			if err != nil {
+				return nil, err
+			}
 
 			newDocument, err := documentAsset.PutNew(stub)
 			if err != nil {
@@ -155,6 +254,20 @@ var PutSignature = tx.Transaction{
 			}
 
 			response["document"] = newDocument
+
+			err = putDocumentSignature(stub, newDocument, signerAsset, delegation, req)
+			if err != nil {
+				return nil, err
+			}
+
+			event := eventtypes.SignatureAdded
+			if documentAsset["status"] == datatypes.StatusType(3) {
+				event = eventtypes.DocumentSigned
+			}
+			err = eventtypes.Emit(stub, event, signatureEvent(newDocument, signer, delegation))
+			if err != nil {
+				return nil, errors.WrapError(err, "failed to emit signature event")
+			}
 		}
 
 		resBytes, e := json.Marshal(response)
@@ -165,3 +278,114 @@ var PutSignature = tx.Transaction{
 		return resBytes, nil
 	},
 }
+
+// putDocumentSignature verifies the signature of the document originalHash with the public key
+// registered by the signer, by the delegate on delegated signatures or by the representative on
+// company signatures, and records it on the ledger
+func putDocumentSignature(stub *sw.StubWrapper, document map[string]interface{}, signer map[string]interface{}, delegation map[string]interface{}, req map[string]interface{}) errors.ICCError {
+	signerKey := assets.Key{
+		"@assetType": "user",
+		"@key":       signer["@key"],
+	}
+
+	keyOwner := signerKey
+	var representative string
+	if delegation != nil {
+		keyOwner = assets.Key{
+			"@assetType": "user",
+			"@key":       utils.RefKeys([]interface{}{delegation["delegate"]})[0],
+		}
+	} else {
+		// Companies sign with the key of the representative signing on their behalf
+		var err errors.ICCError
+		representative, err = utils.CallerRepresentative(stub, signerKey)
+		if err != nil {
+			return err
+		}
+		if representative != "" {
+			keyOwner = assets.Key{
+				"@assetType": "user",
+				"@key":       representative,
+			}
+		}
+	}
+	keyOwnerUser, err := keyOwner.Get(stub)
+	if err != nil {
+		return errors.WrapError(err, "Failed to get signer from the ledger")
+	}
+
+	publicKey, _ := (*keyOwnerUser)["publicKey"].(string)
+	if publicKey == "" {
+		return errors.NewCCError("Signer has no registered public key", 400)
+	}
+
+	originalHash, _ := document["originalHash"].(string)
+	hash, nerr := hex.DecodeString(originalHash)
+	if nerr != nil {
+		return errors.WrapErrorWithStatus(nerr, "Invalid document hash", 400)
+	}
+
+	signatureB64, _ := req["signature"].(string)
+	signature, nerr := base64.StdEncoding.DecodeString(signatureB64)
+	if nerr != nil {
+		return errors.WrapErrorWithStatus(nerr, "Signature must be base64 encoded", 400)
+	}
+
+	algorithm, _ := req["algorithm"].(string)
+	algorithm, err = utils.VerifySignature(publicKey, algorithm, hash, signature)
+	if err != nil {
+		return err
+	}
+
+	signedAt, err := txTime(stub)
+	if err != nil {
+		return err
+	}
+
+	evidence := map[string]interface{}{
+		"@assetType": "signature",
+		"document": map[string]interface{}{
+			"@assetType": "document",
+			"@key":       document["@key"],
+		},
+		"signer":    signerKey,
+		"signature": signatureB64,
+		"algorithm": algorithm,
+		"publicKey": publicKey,
+		"signedAt":  signedAt.Format(time.RFC3339),
+	}
+	if delegation != nil {
+		evidence["delegate"] = keyOwner
+		evidence["delegation"] = map[string]interface{}{
+			"@assetType": "delegation",
+			"@key":       delegation["@key"],
+		}
+	}
+	if representative != "" {
+		evidence["representative"] = keyOwner
+	}
+
+	signatureAsset, err := assets.NewAsset(evidence)
+	if err != nil {
+		return errors.WrapError(err, "Failed to create signature asset")
+	}
+
+	// The evidence of a signature is never replaced
+	_, err = signatureAsset.PutNew(stub)
+	if err != nil {
+		return errors.WrapError(err, "Failed to write signature to the ledger")
+	}
+
+	return nil
+}
+
+// signatureEvent returns the event of a signature, naming the delegate on delegated signatures
+func signatureEvent(document map[string]interface{}, signer string, delegation map[string]interface{}) eventtypes.DocumentEvent {
+	event := documentEvent(document, signer)
+	if delegation != nil {
+		if delegate := utils.RefKeys([]interface{}{delegation["delegate"]}); len(delegate) == 1 {
+			event.Delegate = delegate[0]
+		}
+	}
+	return event
+}
@@ -0,0 +1,162 @@
+package document
+
+import (
+	"fmt"
+
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+// quorum is satisfied when at least Required of its signers signed the document
+type quorum struct {
+	Name     string
+	Signers  []string
+	Required int
+}
+
+// parseQuorums reads the quorum rules of a document, e.g.
+// [{"name": "directors", "signers": [director1, director2, director3], "required": 2}]
+func parseQuorums(quorums interface{}) ([]quorum, errors.ICCError) {
+	rules, _ := quorums.([]interface{})
+
+	parsed := make([]quorum, 0, len(rules))
+	for i, r := range rules {
+		rule, ok := r.(map[string]interface{})
+		if !ok {
+			return nil, errors.NewCCError(fmt.Sprintf("Quorum %d must be an object", i+1), 400)
+		}
+
+		name, _ := rule["name"].(string)
+		if name == "" {
+			name = fmt.Sprintf("quorum %d", i+1)
+		}
+
+		signers, ok := rule["signers"].([]interface{})
+		if !ok || len(signers) == 0 {
+			return nil, errors.NewCCError(fmt.Sprintf("Quorum '%s' must have a list of signers", name), 400)
+		}
+
+		// Signers are read the same way as a signing order step
+		group, err := parseSigningOrder([]interface{}{map[string]interface{}{"signers": signers}})
+		if err != nil {
+			return nil, errors.WrapError(err, fmt.Sprintf("Invalid signers on quorum '%s'", name))
+		}
+
+		required, _ := rule["required"].(float64)
+		if required < 1 || int(required) > len(signers) || required != float64(int(required)) {
+			return nil, errors.NewCCError(fmt.Sprintf("Quorum '%s' must require between 1 and %d signatures", name, len(signers)), 400)
+		}
+
+		parsed = append(parsed, quorum{
+			Name:     name,
+			Signers:  group[0],
+			Required: int(required),
+		})
+	}
+
+	return parsed, nil
+}
+
+// checkQuorums fails unless the quorum signers are required signers of the document
+func checkQuorums(quorums []quorum, requiredSignatures []interface{}) errors.ICCError {
+	required := make(map[string]bool)
+	for _, key := range utils.RefKeys(requiredSignatures) {
+		required[key] = true
+	}
+
+	for _, q := range quorums {
+		listed := make(map[string]bool)
+		for _, key := range q.Signers {
+			if !required[key] {
+				return errors.NewCCError(fmt.Sprintf("Signer %s of quorum '%s' is not a required signer", key, q.Name), 400)
+			}
+			if listed[key] {
+				return errors.NewCCError(fmt.Sprintf("Signer %s appears more than once in quorum '%s'", key, q.Name), 400)
+			}
+			listed[key] = true
+		}
+	}
+
+	return nil
+}
+
+// pendingSigners returns the required signers whose signature is still needed, in the order of
+// requiredSignatures, and how many quorums are met. Signers that belong to quorums are only
+// needed while one of their quorums is not met; every other required signer must sign.
+func pendingSigners(document map[string]interface{}) ([]string, int, errors.ICCError) {
+	signed := make(map[string]bool)
+	for _, key := range utils.RefKeys(document["successfulSignatures"]) {
+		signed[key] = true
+	}
+
+	quorums, err := parseQuorums(document["quorums"])
+	if err != nil {
+		return nil, 0, err
+	}
+
+	inQuorum := make(map[string]bool)
+	unmet := make(map[string]bool)
+	met := 0
+	for _, q := range quorums {
+		count := 0
+		for _, key := range q.Signers {
+			inQuorum[key] = true
+			if signed[key] {
+				count++
+			}
+		}
+		if count >= q.Required {
+			met++
+			continue
+		}
+		for _, key := range q.Signers {
+			unmet[key] = true
+		}
+	}
+
+	pending := []string{}
+	for _, key := range utils.RefKeys(document["requiredSignatures"]) {
+		if signed[key] {
+			continue
+		}
+		if !inQuorum[key] || unmet[key] {
+			pending = append(pending, key)
+		}
+	}
+
+	return pending, met, nil
+}
+
+// signatureStatus returns the status of a document waiting for signatures: finalized when no
+// signature is pending, partially finalized when some but not all of its quorums are met
+func signatureStatus(document map[string]interface{}) (datatypes.StatusType, errors.ICCError) {
+	pending, met, err := pendingSigners(document)
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case len(pending) == 0:
+		return datatypes.StatusType(3), nil
+	case met > 0:
+		return datatypes.StatusType(4), nil
+	default:
+		return datatypes.StatusType(0), nil
+	}
+}
+
+// acceptsSignatures tells whether the document is still collecting signatures. A partially
+// finalized document keeps collecting them unless a rejection halted it.
+func acceptsSignatures(document map[string]interface{}) bool {
+	status, _ := datatypes.ParseStatus(document["status"])
+	switch status {
+	case 0:
+		return true
+	case 4:
+		rejected, _ := document["rejectedSignatures"].([]interface{})
+		return len(rejected) == 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,166 @@
+package document
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
+)
+
+func userRef(key string) map[string]interface{} {
+	return map[string]interface{}{"@assetType": "user", "@key": key}
+}
+
+func userRefs(keys ...string) []interface{} {
+	refs := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		refs = append(refs, userRef(key))
+	}
+	return refs
+}
+
+// testDocument is signed first by user:a and then by a quorum of two of user:b, user:c and user:d
+func testDocument(status datatypes.StatusType, signed ...string) map[string]interface{} {
+	return map[string]interface{}{
+		"@assetType":           "document",
+		"@key":                 "document:test",
+		"status":               status,
+		"requiredSignatures":   userRefs("user:a", "user:b", "user:c", "user:d"),
+		"successfulSignatures": userRefs(signed...),
+		"signingOrder": []interface{}{
+			map[string]interface{}{"signers": userRefs("user:a")},
+			map[string]interface{}{"signers": userRefs("user:b", "user:c", "user:d")},
+		},
+		"quorums": []interface{}{
+			map[string]interface{}{"name": "directors", "signers": userRefs("user:b", "user:c", "user:d"), "required": float64(2)},
+		},
+	}
+}
+
+// roundTrip returns the document as read back from the ledger as raw JSON
+func roundTrip(t *testing.T, document map[string]interface{}) map[string]interface{} {
+	t.Helper()
+
+	documentJSON, err := json.Marshal(document)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	var stored map[string]interface{}
+	if err := json.Unmarshal(documentJSON, &stored); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if _, ok := stored["status"].(float64); !ok {
+		t.Fatalf("status read back as %T, want float64", stored["status"])
+	}
+
+	return stored
+}
+
+func TestAcceptsSignatures(t *testing.T) {
+	tests := []struct {
+		status   datatypes.StatusType
+		rejected bool
+		want     bool
+	}{
+		{0, false, true},
+		{1, false, false},
+		{2, false, false},
+		{3, false, false},
+		{4, false, true},
+		{4, true, false},
+	}
+
+	for _, tt := range tests {
+		document := testDocument(tt.status)
+		if tt.rejected {
+			document["rejectedSignatures"] = userRefs("user:b")
+		}
+
+		if got := acceptsSignatures(document); got != tt.want {
+			t.Errorf("acceptsSignatures(status %v, rejected %v) = %v, want %v", tt.status, tt.rejected, got, tt.want)
+		}
+		if got := acceptsSignatures(roundTrip(t, document)); got != tt.want {
+			t.Errorf("acceptsSignatures(stored status %v, rejected %v) = %v, want %v", tt.status, tt.rejected, got, tt.want)
+		}
+	}
+}
+
+func TestSignatureStatus(t *testing.T) {
+	tests := []struct {
+		signed []string
+		want   datatypes.StatusType
+	}{
+		{nil, 0},
+		{[]string{"user:a"}, 0},
+		{[]string{"user:a", "user:b"}, 0},
+		{[]string{"user:b", "user:c"}, 4},
+		{[]string{"user:a", "user:b", "user:d"}, 3},
+	}
+
+	for _, tt := range tests {
+		got, err := signatureStatus(roundTrip(t, testDocument(0, tt.signed...)))
+		if err != nil {
+			t.Fatalf("signatureStatus(%v) error = %v", tt.signed, err)
+		}
+		if got != tt.want {
+			t.Errorf("signatureStatus(%v) = %v, want %v", tt.signed, got, tt.want)
+		}
+	}
+}
+
+func TestPendingSigners(t *testing.T) {
+	pending, met, err := pendingSigners(roundTrip(t, testDocument(0, "user:b")))
+	if err != nil {
+		t.Fatalf("pendingSigners() error = %v", err)
+	}
+	if want := []string{"user:a", "user:c", "user:d"}; !reflect.DeepEqual(pending, want) || met != 0 {
+		t.Errorf("pendingSigners() = %v, %d, want %v, 0", pending, met, want)
+	}
+
+	pending, met, err = pendingSigners(roundTrip(t, testDocument(0, "user:b", "user:c")))
+	if err != nil {
+		t.Fatalf("pendingSigners() error = %v", err)
+	}
+	if want := []string{"user:a"}; !reflect.DeepEqual(pending, want) || met != 1 {
+		t.Errorf("pendingSigners() = %v, %d, want %v, 1", pending, met, want)
+	}
+}
+
+func TestParseQuorums(t *testing.T) {
+	tests := []struct {
+		name    string
+		quorums interface{}
+		wantErr bool
+	}{
+		{"none", nil, false},
+		{"valid", []interface{}{map[string]interface{}{"signers": userRefs("user:b", "user:c"), "required": float64(1)}}, false},
+		{"not an object", []interface{}{"directors"}, true},
+		{"no signers", []interface{}{map[string]interface{}{"required": float64(1)}}, true},
+		{"requires too many", []interface{}{map[string]interface{}{"signers": userRefs("user:b"), "required": float64(2)}}, true},
+		{"requires none", []interface{}{map[string]interface{}{"signers": userRefs("user:b"), "required": float64(0)}}, true},
+		{"fractional", []interface{}{map[string]interface{}{"signers": userRefs("user:b", "user:c"), "required": 1.5}}, true},
+	}
+
+	for _, tt := range tests {
+		_, err := parseQuorums(tt.quorums)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: parseQuorums() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCheckQuorums(t *testing.T) {
+	required := userRefs("user:a", "user:b", "user:c")
+
+	if err := checkQuorums([]quorum{{Name: "q", Signers: []string{"user:b", "user:c"}, Required: 1}}, required); err != nil {
+		t.Errorf("checkQuorums() error = %v", err)
+	}
+	if err := checkQuorums([]quorum{{Name: "q", Signers: []string{"user:b", "user:x"}, Required: 1}}, required); err == nil {
+		t.Errorf("checkQuorums() accepted a signer that is not required")
+	}
+	if err := checkQuorums([]quorum{{Name: "q", Signers: []string{"user:b", "user:b"}, Required: 1}}, required); err == nil {
+		t.Errorf("checkQuorums() accepted a repeated signer")
+	}
+}
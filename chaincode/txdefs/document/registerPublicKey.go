@@ -0,0 +1,73 @@
+package document
+
+import (
+	"encoding/json"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+var RegisterPublicKey = tx.Transaction{
+	Tag:         "registerPublicKey",
+	Label:       "Register Public Key",
+	Description: "Registers the public key used to verify the user signatures on documents. Signatures already on the ledger keep the key they were verified against",
+	Method:      "POST",
+
+	Args: []tx.Argument{
+		{
+			Tag:      "user",
+			Label:    "User",
+			Required: true,
+			DataType: "->user",
+		},
+		{
+			Tag:         "publicKey",
+			Label:       "Public Key",
+			Required:    true,
+			DataType:    "pemPubKey",
+			Description: "PEM encoded ECDSA, Ed25519 or RSA public key",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		userKey, ok := req["user"].(assets.Key)
+		if !ok {
+			return nil, errors.NewCCError("Failed to get user parameter", 400)
+		}
+
+		publicKey, ok := req["publicKey"].(string)
+		if !ok {
+			return nil, errors.NewCCError("Failed to get publicKey parameter", 400)
+		}
+
+		// Users can only register their own key
+		err := utils.CheckCallerIsUser(stub, userKey)
+		if err != nil {
+			return nil, err
+		}
+
+		user, err := userKey.Get(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get user from the ledger")
+		}
+		if (*user)["company"] != nil {
+			return nil, errors.NewCCError("Companies sign with the keys of their legal representatives", 400)
+		}
+
+		updatedUser, err := userKey.Update(stub, map[string]interface{}{
+			"publicKey": publicKey,
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to update user asset in the ledger")
+		}
+
+		resBytes, e := json.Marshal(updatedUser)
+		if e != nil {
+			return nil, errors.WrapError(e, "failed to marshal response")
+		}
+
+		return resBytes, nil
+	},
+}
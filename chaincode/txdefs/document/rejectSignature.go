@@ -0,0 +1,161 @@
+package document
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/eventtypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+// Rejection policies of a document
+const (
+	RejectionCancel            = "cancel"
+	RejectionPartiallyFinalize = "partiallyFinalize"
+)
+
+var RejectSignature = tx.Transaction{
+	Tag:         "rejectSignature",
+	Label:       "Reject Signature",
+	Description: "Rejects signing a document. The document is cancelled or partially finalized according to its rejection policy",
+	Method:      "POST",
+
+	Args: []tx.Argument{
+		{
+			Tag:      "document",
+			Label:    "Document",
+			Required: true,
+			DataType: "->document",
+		},
+		{
+			Tag:      "user",
+			Label:    "User",
+			Required: true,
+			DataType: "->user",
+		},
+		{
+			Tag:      "reason",
+			Label:    "Reason",
+			Required: true,
+			DataType: "string",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		documentKey, ok := req["document"].(assets.Key)
+		if !ok {
+			return nil, errors.NewCCError("Failed to get document parameter", 400)
+		}
+
+		signerKey, ok := req["user"].(assets.Key)
+		if !ok {
+			return nil, errors.NewCCError("Failed to get user parameter", 400)
+		}
+
+		reason, ok := req["reason"].(string)
+		if !ok || reason == "" {
+			return nil, errors.NewCCError("A reason must be given to reject a document", 400)
+		}
+
+		// Only the signer themself can reject
+		err := utils.CheckCallerIsUser(stub, signerKey)
+		if err != nil {
+			return nil, err
+		}
+
+		document, err := documentKey.Get(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get document from the ledger")
+		}
+
+		if !acceptsSignatures(*document) {
+			return nil, errors.NewCCError("Document is not waiting for signatures", 400)
+		}
+
+		if (*document)["supersededBy"] != nil {
+			return nil, errors.NewCCError("Document was amended, reject its latest version", 400)
+		}
+
+		signer := signerKey.Key()
+		isSignerRequired := false
+		for _, key := range utils.RefKeys((*document)["requiredSignatures"]) {
+			if key == signer {
+				isSignerRequired = true
+				break
+			}
+		}
+		if !isSignerRequired {
+			return nil, errors.NewCCError("Signer is not required for this document", 400)
+		}
+
+		for _, key := range utils.RefKeys((*document)["successfulSignatures"]) {
+			if key == signer {
+				return nil, errors.NewCCError("Signer already signed this document", 400)
+			}
+		}
+
+		rejectedAt, err := txTime(stub)
+		if err != nil {
+			return nil, err
+		}
+
+		signerRef := map[string]interface{}{
+			"@assetType": "user",
+			"@key":       signer,
+		}
+
+		rejectedSignatures, _ := (*document)["rejectedSignatures"].([]interface{})
+		rejections, _ := (*document)["rejections"].([]interface{})
+
+		status := datatypes.StatusType(1)
+		if policy, _ := (*document)["rejectionPolicy"].(string); policy == RejectionPartiallyFinalize {
+			status = datatypes.StatusType(4)
+		}
+
+		updatedDocument, err := documentKey.Update(stub, map[string]interface{}{
+			"status":             status,
+			"rejectedSignatures": append(rejectedSignatures, signerRef),
+			"rejections": append(rejections, map[string]interface{}{
+				"signer":    signerRef,
+				"reason":    reason,
+				"timestamp": rejectedAt.Format(time.RFC3339),
+			}),
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to update document")
+		}
+
+		event := documentEvent(updatedDocument, signer)
+		event.Reason = reason
+		err = eventtypes.Emit(stub, eventtypes.SignatureRejected, event)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to emit signature rejected event")
+		}
+
+		resBytes, nerr := json.Marshal(updatedDocument)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		return resBytes, nil
+	},
+}
+
+// signerRejection returns the rejection of the signer recorded on the document, if any
+func signerRejection(document map[string]interface{}, signer string) map[string]interface{} {
+	rejections, _ := document["rejections"].([]interface{})
+	for _, r := range rejections {
+		rejection, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if keys := utils.RefKeys([]interface{}{rejection["signer"]}); len(keys) == 1 && keys[0] == signer {
+			return rejection
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package document
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+var RevokeConsent = tx.Transaction{
+	Tag:         "revokeConsent",
+	Label:       "Revoke Consent",
+	Description: "Revokes a consent. The consent record is kept as evidence of the period it was in force",
+	Method:      "POST",
+
+	Args: []tx.Argument{
+		{
+			Tag:      "consent",
+			Label:    "Consent",
+			Required: true,
+			DataType: "->consent",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		consentKey, ok := req["consent"].(assets.Key)
+		if !ok {
+			return nil, errors.NewCCError("Failed to get consent parameter", http.StatusBadRequest)
+		}
+
+		consent, err := consentKey.Get(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get consent from the ledger")
+		}
+
+		// Only the user revokes their consent
+		err = utils.CheckCallerIsUser(stub, (*consent)["user"])
+		if err != nil {
+			return nil, err
+		}
+
+		if _, revoked := (*consent)["revokedAt"]; revoked {
+			return nil, errors.NewCCError("Consent is already revoked", http.StatusBadRequest)
+		}
+
+		now, err := txTime(stub)
+		if err != nil {
+			return nil, err
+		}
+
+		updated, err := consentKey.Update(stub, map[string]interface{}{
+			"revokedAt": now.Format(time.RFC3339),
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to update consent")
+		}
+
+		resBytes, nerr := json.Marshal(updated)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		return resBytes, nil
+	},
+}
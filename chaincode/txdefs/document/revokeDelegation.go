@@ -0,0 +1,69 @@
+package document
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+var RevokeDelegation = tx.Transaction{
+	Tag:         "revokeDelegation",
+	Label:       "Revoke Delegation",
+	Description: "Revokes a delegation. Signatures already made by the delegate are kept",
+	Method:      "POST",
+
+	Args: []tx.Argument{
+		{
+			Tag:      "delegation",
+			Label:    "Delegation",
+			Required: true,
+			DataType: "->delegation",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		delegationKey, ok := req["delegation"].(assets.Key)
+		if !ok {
+			return nil, errors.NewCCError("Failed to get delegation parameter", 400)
+		}
+
+		delegation, err := delegationKey.Get(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get delegation from the ledger")
+		}
+
+		// Only the grantor revokes a delegation
+		err = utils.CheckCallerIsUser(stub, (*delegation)["grantor"])
+		if err != nil {
+			return nil, err
+		}
+
+		if revoked, _ := (*delegation)["revoked"].(bool); revoked {
+			return nil, errors.NewCCError("Delegation is already revoked", 400)
+		}
+
+		now, err := txTime(stub)
+		if err != nil {
+			return nil, err
+		}
+
+		updated, err := delegationKey.Update(stub, map[string]interface{}{
+			"revoked":   true,
+			"revokedAt": now.Format(time.RFC3339),
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to update delegation")
+		}
+
+		resBytes, nerr := json.Marshal(updated)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		return resBytes, nil
+	},
+}
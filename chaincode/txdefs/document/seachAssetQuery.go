@@ -15,6 +15,7 @@ var SearchAssetQuery = tx.Transaction{
 	Label:       "Search for asset through query",
 	Description: "",
 	Method:      "GET",
+	ReadOnly:    true,
 
 	Args: []tx.Argument{
 		{
@@ -0,0 +1,84 @@
+package document
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+// documentSortFields maps the fields documents can be sorted by to their CouchDB index
+var documentSortFields = map[string]string{
+	"name":    "indexName",
+	"status":  "indexStatus",
+	"timeout": "indexTimeout",
+}
+
+var SearchDocuments = tx.Transaction{
+	Tag:         "searchDocuments",
+	Label:       "Search Documents",
+	Description: "Returns a page of documents filtered by owner, required signer and status",
+	Method:      "GET",
+	ReadOnly:    true,
+
+	Args: append([]tx.Argument{
+		{
+			Tag:      "owner",
+			Label:    "Owner",
+			DataType: "->user",
+		},
+		{
+			Tag:      "signer",
+			Label:    "Signer",
+			DataType: "->user",
+		},
+		{
+			Tag:      "status",
+			Label:    "Status",
+			DataType: "statusType",
+		},
+	}, utils.PaginationArgs...),
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		selector := map[string]interface{}{
+			"@assetType": "document",
+		}
+
+		if owner, ok := req["owner"].(assets.Key); ok {
+			selector["owner.@key"] = owner.Key()
+		}
+
+		if signer, ok := req["signer"].(assets.Key); ok {
+			selector["requiredSignatures"] = map[string]interface{}{
+				"$elemMatch": map[string]interface{}{
+					"@key": signer.Key(),
+				},
+			}
+		}
+
+		if status, ok := req["status"].(datatypes.StatusType); ok {
+			selector["status"] = status
+		}
+
+		query, err := utils.PaginatedQuery(req, selector, documentSortFields)
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := assets.Search(stub, query, "", false)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "error searching for documents", http.StatusInternalServerError)
+		}
+
+		responseJSON, nerr := json.Marshal(response)
+		if nerr != nil {
+			return nil, errors.WrapErrorWithStatus(nerr, "error marshaling response", http.StatusInternalServerError)
+		}
+
+		return responseJSON, nil
+	},
+}
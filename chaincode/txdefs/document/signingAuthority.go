@@ -0,0 +1,87 @@
+package document
+
+import (
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+// checkSigningAuthority fails unless the caller is the signer or, when a delegation is given, the
+// delegate of a valid delegation of the signer covering the document. It returns the delegation used.
+func checkSigningAuthority(stub *sw.StubWrapper, req map[string]interface{}, signer, document string, owner interface{}) (map[string]interface{}, errors.ICCError) {
+	delegationKey, ok := req["delegation"].(assets.Key)
+	if !ok {
+		return nil, utils.CheckCallerIsUser(stub, assets.Key{"@assetType": "user", "@key": signer})
+	}
+
+	delegationAsset, err := delegationKey.Get(stub)
+	if err != nil {
+		return nil, errors.WrapError(err, "Failed to get delegation from the ledger")
+	}
+	delegation := map[string]interface{}(*delegationAsset)
+
+	if grantor := utils.RefKeys([]interface{}{delegation["grantor"]}); len(grantor) != 1 || grantor[0] != signer {
+		return nil, errors.NewCCError("Delegation was not granted by the signer", 403)
+	}
+
+	if revoked, _ := delegation["revoked"].(bool); revoked {
+		return nil, errors.NewCCError("Delegation was revoked", 403)
+	}
+
+	now, err := txTime(stub)
+	if err != nil {
+		return nil, err
+	}
+	validFrom, ok := propTime(delegation["validFrom"])
+	if !ok {
+		return nil, errors.NewCCError("Invalid delegation validFrom", 500)
+	}
+	validUntil, ok := propTime(delegation["validUntil"])
+	if !ok {
+		return nil, errors.NewCCError("Invalid delegation validUntil", 500)
+	}
+	if now.Before(validFrom) || now.After(validUntil) {
+		return nil, errors.NewCCError("Delegation is not valid at this time", 403)
+	}
+
+	covered := false
+	switch delegation["scope"] {
+	case DelegationDocument:
+		keys := utils.RefKeys([]interface{}{delegation["document"]})
+		covered = len(keys) == 1 && keys[0] == document
+	case DelegationOwner:
+		keys := utils.RefKeys([]interface{}{delegation["documentOwner"]})
+		owners := utils.RefKeys([]interface{}{owner})
+		covered = len(keys) == 1 && len(owners) == 1 && keys[0] == owners[0]
+	case DelegationAll:
+		covered = true
+	}
+	if !covered {
+		return nil, errors.NewCCError("Delegation does not cover this document", 403)
+	}
+
+	// The delegate signs in their own name
+	err = utils.CheckCallerIsUser(stub, delegation["delegate"])
+	if err != nil {
+		return nil, err
+	}
+
+	return delegation, nil
+}
+
+// propTime reads a datetime property, which is a time.Time on assets read from the ledger
+// and a RFC3339 string on raw query results
+func propTime(prop interface{}) (time.Time, bool) {
+	switch t := prop.(type) {
+	case time.Time:
+		return t, true
+	case string:
+		parsed, err := time.Parse(time.RFC3339, t)
+		return parsed, err == nil
+	default:
+		return time.Time{}, false
+	}
+}
@@ -0,0 +1,154 @@
+package document
+
+import (
+	"fmt"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+// parseSigningOrder returns the user keys of each step of a document signing order.
+// Every step is an object with the list of signers that can sign in parallel, e.g.
+// [{"signers": [company]}, {"signers": [client]}, {"signers": [witness1, witness2]}].
+func parseSigningOrder(signingOrder interface{}) ([][]string, errors.ICCError) {
+	steps, _ := signingOrder.([]interface{})
+
+	order := make([][]string, 0, len(steps))
+	for i, s := range steps {
+		step, ok := s.(map[string]interface{})
+		if !ok {
+			return nil, errors.NewCCError(fmt.Sprintf("Signing order step %d must be an object", i+1), 400)
+		}
+
+		signers, ok := step["signers"].([]interface{})
+		if !ok || len(signers) == 0 {
+			return nil, errors.NewCCError(fmt.Sprintf("Signing order step %d must have a list of signers", i+1), 400)
+		}
+
+		group := make([]string, 0, len(signers))
+		for _, signer := range signers {
+			var key string
+			switch ref := signer.(type) {
+			case assets.Key:
+				key = ref.Key()
+			case map[string]interface{}:
+				userRef := make(map[string]interface{}, len(ref)+1)
+				for k, v := range ref {
+					userRef[k] = v
+				}
+				userRef["@assetType"] = "user"
+				userKey, err := assets.NewKey(userRef)
+				if err != nil {
+					return nil, errors.WrapErrorWithStatus(err, fmt.Sprintf("Invalid signer on signing order step %d", i+1), 400)
+				}
+				key = userKey.Key()
+			default:
+				return nil, errors.NewCCError(fmt.Sprintf("Invalid signer on signing order step %d", i+1), 400)
+			}
+			group = append(group, key)
+		}
+
+		order = append(order, group)
+	}
+
+	return order, nil
+}
+
+// checkSigningOrder fails unless every required signer appears exactly once in the signing order
+func checkSigningOrder(order [][]string, requiredSignatures []interface{}) errors.ICCError {
+	required := make(map[string]bool)
+	for _, key := range utils.RefKeys(requiredSignatures) {
+		required[key] = true
+	}
+
+	listed := make(map[string]bool)
+	for i, group := range order {
+		for _, key := range group {
+			if !required[key] {
+				return errors.NewCCError(fmt.Sprintf("Signer %s on signing order step %d is not a required signer", key, i+1), 400)
+			}
+			if listed[key] {
+				return errors.NewCCError(fmt.Sprintf("Signer %s appears more than once in the signing order", key), 400)
+			}
+			listed[key] = true
+		}
+	}
+
+	for key := range required {
+		if !listed[key] {
+			return errors.NewCCError(fmt.Sprintf("Required signer %s is missing from the signing order", key), 400)
+		}
+	}
+
+	return nil
+}
+
+// expectedSigners returns the signers whose signature is currently expected and the step of
+// the signing order they belong to. Without a signing order every pending signer is expected
+// and the step is zero. A step is over once none of its signers is pending, which for quorum
+// signers means once their quorums are met.
+func expectedSigners(document map[string]interface{}) ([]string, int, errors.ICCError) {
+	if !acceptsSignatures(document) {
+		return []string{}, 0, nil
+	}
+
+	pending, _, err := pendingSigners(document)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	order, err := parseSigningOrder(document["signingOrder"])
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(order) == 0 {
+		return pending, 0, nil
+	}
+
+	isPending := make(map[string]bool)
+	for _, key := range pending {
+		isPending[key] = true
+	}
+
+	for i, group := range order {
+		expected := []string{}
+		for _, key := range group {
+			if isPending[key] {
+				expected = append(expected, key)
+			}
+		}
+		if len(expected) > 0 {
+			return expected, i + 1, nil
+		}
+	}
+
+	return []string{}, 0, nil
+}
+
+// checkSignerTurn fails if the signer is not expected to sign the document at this point of the signing order
+func checkSignerTurn(document map[string]interface{}, signer string) errors.ICCError {
+	// Signers carried over from a previous version already signed in their turn
+	for _, key := range utils.RefKeys(document["carriedOverSignatures"]) {
+		if key == signer {
+			return nil
+		}
+	}
+
+	expected, step, err := expectedSigners(document)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range expected {
+		if key == signer {
+			return nil
+		}
+	}
+
+	if step > 0 {
+		return errors.NewCCError(fmt.Sprintf("It is not the signer's turn, step %d of the signing order is still pending", step), 400)
+	}
+	return errors.NewCCError("Signer is not expected to sign this document", 400)
+}
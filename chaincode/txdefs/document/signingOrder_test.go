@@ -0,0 +1,79 @@
+package document
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
+)
+
+func TestCheckSigningOrder(t *testing.T) {
+	required := userRefs("user:a", "user:b")
+
+	tests := []struct {
+		name    string
+		order   [][]string
+		wantErr bool
+	}{
+		{"valid", [][]string{{"user:a"}, {"user:b"}}, false},
+		{"parallel", [][]string{{"user:a", "user:b"}}, false},
+		{"not required", [][]string{{"user:a"}, {"user:b", "user:x"}}, true},
+		{"repeated", [][]string{{"user:a"}, {"user:a", "user:b"}}, true},
+		{"missing", [][]string{{"user:a"}}, true},
+	}
+
+	for _, tt := range tests {
+		err := checkSigningOrder(tt.order, required)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: checkSigningOrder() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestExpectedSigners(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   datatypes.StatusType
+		signed   []string
+		want     []string
+		wantStep int
+	}{
+		{"first step", 0, nil, []string{"user:a"}, 1},
+		{"quorum step", 0, []string{"user:a"}, []string{"user:b", "user:c", "user:d"}, 2},
+		{"quorum partly signed", 0, []string{"user:a", "user:c"}, []string{"user:b", "user:d"}, 2},
+		{"quorum met first", 4, []string{"user:b", "user:c"}, []string{"user:a"}, 1},
+		{"cancelled", 1, nil, []string{}, 0},
+		{"expired", 2, []string{"user:a"}, []string{}, 0},
+	}
+
+	for _, tt := range tests {
+		got, step, err := expectedSigners(roundTrip(t, testDocument(tt.status, tt.signed...)))
+		if err != nil {
+			t.Fatalf("%s: expectedSigners() error = %v", tt.name, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) || step != tt.wantStep {
+			t.Errorf("%s: expectedSigners() = %v, %d, want %v, %d", tt.name, got, step, tt.want, tt.wantStep)
+		}
+	}
+}
+
+func TestCheckSignerTurn(t *testing.T) {
+	document := roundTrip(t, testDocument(0))
+
+	if err := checkSignerTurn(document, "user:a"); err != nil {
+		t.Errorf("checkSignerTurn(user:a) error = %v", err)
+	}
+	if err := checkSignerTurn(document, "user:b"); err == nil {
+		t.Errorf("checkSignerTurn(user:b) accepted a signer out of turn")
+	}
+
+	document["carriedOverSignatures"] = userRefs("user:b")
+	if err := checkSignerTurn(document, "user:b"); err != nil {
+		t.Errorf("checkSignerTurn(carried over user:b) error = %v", err)
+	}
+
+	cancelled := roundTrip(t, testDocument(1))
+	if err := checkSignerTurn(cancelled, "user:a"); err == nil {
+		t.Errorf("checkSignerTurn() accepted a signature on a cancelled document")
+	}
+}
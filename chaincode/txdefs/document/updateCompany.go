@@ -0,0 +1,92 @@
+package document
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
+)
+
+var UpdateCompany = tx.Transaction{
+	Tag:         "updateCompany",
+	Label:       "Update Company",
+	Description: "Updates the names and legal representatives of a company",
+	Method:      "POST",
+
+	Args: []tx.Argument{
+		{
+			Tag:      "company",
+			Label:    "Company",
+			Required: true,
+			DataType: "->company",
+		},
+		{
+			Tag:      "legalName",
+			Label:    "Legal Name",
+			DataType: "string",
+		},
+		{
+			Tag:      "tradeName",
+			Label:    "Trade Name",
+			DataType: "string",
+		},
+		{
+			Tag:         "representatives",
+			Label:       "Legal Representatives",
+			DataType:    "[]->user",
+			Description: "Replaces the current legal representatives",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		companyKey, ok := req["company"].(assets.Key)
+		if !ok {
+			return nil, errors.NewCCError("Failed to get company parameter", http.StatusBadRequest)
+		}
+
+		company, err := companyKey.Get(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get company from the ledger")
+		}
+
+		// Only a current legal representative updates the company
+		current, _ := (*company)["representatives"].([]interface{})
+		err = utils.CheckCallerIsAnyUser(stub, current)
+		if err != nil {
+			return nil, err
+		}
+
+		updates := make(map[string]interface{})
+		if legalName, ok := req["legalName"].(string); ok && legalName != "" {
+			updates["legalName"] = legalName
+		}
+		if tradeName, ok := req["tradeName"].(string); ok && tradeName != "" {
+			updates["tradeName"] = tradeName
+		}
+		if representatives, ok := req["representatives"].([]interface{}); ok {
+			err = checkRepresentatives(stub, representatives)
+			if err != nil {
+				return nil, err
+			}
+			updates["representatives"] = representatives
+		}
+		if len(updates) == 0 {
+			return nil, errors.NewCCError("Nothing to update", http.StatusBadRequest)
+		}
+
+		updated, err := companyKey.Update(stub, updates)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to update company")
+		}
+
+		resBytes, nerr := json.Marshal(updated)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		return resBytes, nil
+	},
+}
@@ -2,17 +2,42 @@ package document
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/errors"
 	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
 	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
 )
 
+// protectedDocumentProps are only changed by the transactions that sign, reject, cancel, expire or amend
+// a document, since their values decide who must sign it and whether it is binding
+var protectedDocumentProps = map[string]bool{
+	"originalHash":          true,
+	"status":                true,
+	"owner":                 true,
+	"requiredSignatures":    true,
+	"successfulSignatures":  true,
+	"rejectedSignatures":    true,
+	"carriedOverSignatures": true,
+	"signingOrder":          true,
+	"quorums":               true,
+	"rejectionPolicy":       true,
+	"rejections":            true,
+	"version":               true,
+	"previousVersion":       true,
+	"previousHash":          true,
+	"supersededBy":          true,
+}
+
 var UpdateDocument = tx.Transaction{
 	Tag:         "updateDocument",
 	Label:       "Update Document",
-	Description: "",
+	Description: "Changes the name, URLs, final hash or timeout of a document. Only the document owner can update it",
 	Method:      "POST",
 
 	Args: []tx.Argument{
@@ -40,18 +65,32 @@ var UpdateDocument = tx.Transaction{
 			return nil, errors.WrapError(err, "Failed to get document asset from the ledger")
 		}
 
-		documentMap := *documentAsset
+		err = utils.CheckCallerIsUser(stub, (*documentAsset)["owner"])
+		if err != nil {
+			return nil, err
+		}
 
 		updates, ok := req["updates"].(map[string]interface{})
 		if !ok {
 			return nil, errors.WrapError(nil, "Parameter 'updates' must be a map")
 		}
 
-		for key, value := range updates {
-			documentMap[key] = value
+		for key := range updates {
+			if protectedDocumentProps[key] || strings.HasPrefix(key, "@") {
+				return nil, errors.NewCCError(fmt.Sprintf("Document property '%s' cannot be updated", key), http.StatusBadRequest)
+			}
+		}
+
+		// Stored in UTC like the timeout given on upload
+		if timeout, ok := updates["timeout"].(string); ok {
+			t, nerr := time.Parse(time.RFC3339, timeout)
+			if nerr != nil {
+				return nil, errors.WrapErrorWithStatus(nerr, "Invalid timeout", http.StatusBadRequest)
+			}
+			updates["timeout"] = t.UTC()
 		}
 
-		updatedDocument, err := documentKey.Update(stub, documentMap)
+		updatedDocument, err := documentKey.Update(stub, updates)
 		if err != nil {
 			return nil, errors.WrapError(err, "Failed to update document asset in the ledger")
 		}
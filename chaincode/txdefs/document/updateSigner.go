@@ -2,17 +2,19 @@ package document
 
 import (
 	"encoding/json"
+	"net/http"
 
 	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/errors"
 	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
 	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
 )
 
 var UpdateSigner = tx.Transaction{
 	Tag:         "updateSigner",
 	Label:       "Update Signer",
-	Description: "",
+	Description: "Updates the personal data of a user. Email, name, phone and user name must be sent through the transient map. The bound identity changes through bindUserIdentity and the public key through registerPublicKey",
 	Method:      "POST",
 
 	Args: []tx.Argument{
@@ -23,10 +25,28 @@ var UpdateSigner = tx.Transaction{
 			DataType: "->user",
 		},
 		{
-			Tag:      "updates",
-			Label:    "Updates",
-			Required: true,
-			DataType: "@object",
+			Tag:      "email",
+			Label:    "Email",
+			DataType: "string",
+			Private:  true,
+		},
+		{
+			Tag:      "name",
+			Label:    "Name",
+			DataType: "string",
+			Private:  true,
+		},
+		{
+			Tag:      "phone",
+			Label:    "Phone",
+			DataType: "string",
+			Private:  true,
+		},
+		{
+			Tag:      "userName",
+			Label:    "UserName",
+			DataType: "string",
+			Private:  true,
 		},
 	},
 	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
@@ -35,32 +55,37 @@ var UpdateSigner = tx.Transaction{
 			return nil, errors.WrapError(nil, "Parameter 'signer' must be an asset")
 		}
 
-		signerAsset, err := signerKey.Get(stub)
+		// Users can only update their own data
+		err := utils.CheckCallerIsUser(stub, signerKey)
 		if err != nil {
-			return nil, errors.WrapError(err, "Failed to get signer asset from the ledger")
+			return nil, err
 		}
 
-		signerMap := *signerAsset
-
-		updates, ok := req["updates"].(map[string]interface{})
-		if !ok {
-			return nil, errors.WrapError(nil, "Parameter 'updates' must be a map")
+		personalUpdates := make(map[string]interface{})
+		for _, field := range personalDataFields {
+			if value, ok := req[field].(string); ok && value != "" {
+				personalUpdates[field] = value
+			}
+		}
+		if len(personalUpdates) == 0 {
+			return nil, errors.NewCCError("Nothing to update", http.StatusBadRequest)
 		}
 
-		for key, value := range updates {
-			signerMap[key] = value
+		personalKey, err := personalDataKey(signerKey.Key())
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to get personal data key")
 		}
 
-		updatedDocument, err := signerKey.Update(stub, signerMap)
+		updated, err := personalKey.Update(stub, personalUpdates)
 		if err != nil {
-			return nil, errors.WrapError(err, "Failed to update signer asset in the ledger")
+			return nil, errors.WrapError(err, "Failed to update personal data")
 		}
 
-		updatedDocumentJSON, e := json.Marshal(updatedDocument)
+		updatedJSON, e := json.Marshal(updated)
 		if e != nil {
-			return nil, errors.WrapError(err, "Failed to marshal updated document asset")
+			return nil, errors.WrapError(e, "Failed to marshal updated personal data")
 		}
 
-		return updatedDocumentJSON, nil
+		return updatedJSON, nil
 	},
 }
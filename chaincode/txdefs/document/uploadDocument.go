@@ -2,12 +2,15 @@ package document
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/errors"
 	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
 	tx "github.com/hyperledger-labs/cc-tools/transactions"
 	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/eventtypes"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/utils"
 )
 
 var UploadDocument = tx.Transaction{
@@ -50,6 +53,12 @@ var UploadDocument = tx.Transaction{
 			Label:    "rejectedSignatures",
 			DataType: "[]->user",
 		},
+		{
+			Tag:         "signingOrder",
+			Label:       "Signing Order",
+			DataType:    "[]@object",
+			Description: "Optional steps in which the required signers must sign, each with the list of signers that sign in parallel. e.g. [{\"signers\": [company]}, {\"signers\": [client]}]",
+		},
 		{
 			Tag:      "originalDocURL",
 			Label:    "originalDocURL",
@@ -78,6 +87,18 @@ var UploadDocument = tx.Transaction{
 			Label:    "Timeout",
 			DataType: "datetime",
 		},
+		{
+			Tag:         "quorums",
+			Label:       "Quorums",
+			DataType:    "[]@object",
+			Description: "Optional M-of-N rules over the required signers. e.g. [{\"name\": \"directors\", \"signers\": [d1, d2, d3], \"required\": 2}]",
+		},
+		{
+			Tag:         "rejectionPolicy",
+			Label:       "Rejection Policy",
+			DataType:    "string",
+			Description: "cancel (default) cancels the document when a signer rejects it, partiallyFinalize keeps the document and its signatures on record and lets the owner re-issue it by uploading it again",
+		},
 	},
 
 	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
@@ -112,6 +133,19 @@ var UploadDocument = tx.Transaction{
 			return nil, errors.NewCCError("Failed to get owner parameter", 400)
 		}
 		timeout := req["timeout"]
+		// Timeouts are kept in UTC so that expireDocuments can compare them as strings
+		if t, ok := timeout.(time.Time); ok {
+			timeout = t.UTC()
+		}
+
+		// Documents are uploaded by their owner
+		err := utils.CheckCallerIsUser(stub, owner)
+		if err != nil {
+			return nil, err
+		}
+		if len(successfulSignatures) > 0 {
+			return nil, errors.NewCCError("Signatures must be added with putSignature so they can be verified", 400)
+		}
 
 		doc := map[string]interface{}{
 			"@assetType":           "document",
@@ -140,16 +174,71 @@ var UploadDocument = tx.Transaction{
 			doc["rejectedSignatures"] = rejectedSignatures
 		}
 
+		if quorums, ok := req["quorums"].([]interface{}); ok && len(quorums) > 0 {
+			parsed, err := parseQuorums(quorums)
+			if err != nil {
+				return nil, err
+			}
+			err = checkQuorums(parsed, requiredSignatures)
+			if err != nil {
+				return nil, err
+			}
+			doc["quorums"] = quorums
+		}
+
+		if rejectionPolicy, ok := req["rejectionPolicy"].(string); ok && rejectionPolicy != "" {
+			if rejectionPolicy != RejectionCancel && rejectionPolicy != RejectionPartiallyFinalize {
+				return nil, errors.NewCCError("Rejection policy must be cancel or partiallyFinalize", 400)
+			}
+			doc["rejectionPolicy"] = rejectionPolicy
+		}
+
+		if signingOrder, ok := req["signingOrder"].([]interface{}); ok && len(signingOrder) > 0 {
+			order, err := parseSigningOrder(signingOrder)
+			if err != nil {
+				return nil, err
+			}
+			err = checkSigningOrder(order, requiredSignatures)
+			if err != nil {
+				return nil, err
+			}
+			doc["signingOrder"] = signingOrder
+		}
+
 		document, err := assets.NewAsset(doc)
 		if err != nil {
 			return nil, errors.WrapError(err, "failed to create asset")
 		}
 
+		// An existing document can only be re-issued by its owner after a rejection partially finalized it
+		existing, err := document.ExistsInLedger(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to check if document exists in ledger")
+		}
+		if existing {
+			documentKey := assets.Key{"@assetType": "document", "@key": document.Key()}
+			current, err := documentKey.Get(stub)
+			if err != nil {
+				return nil, errors.WrapError(err, "failed to get document from the ledger")
+			}
+			if status, _ := datatypes.ParseStatus((*current)["status"]); status != 4 {
+				return nil, errors.NewCCError("Document already exists, only partially finalized documents can be re-issued", 400)
+			}
+			if currentOwner := utils.RefKeys([]interface{}{(*current)["owner"]}); len(currentOwner) == 0 || currentOwner[0] != owner.Key() {
+				return nil, errors.NewCCError("Only the document owner can re-issue it", 403)
+			}
+		}
+
 		res, err := document.Put(stub)
 		if err != nil {
 			return nil, errors.WrapError(err, "failed to write asset to the ledger")
 		}
 
+		err = eventtypes.Emit(stub, eventtypes.DocumentUploaded, documentEvent(res, ""))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to emit document uploaded event")
+		}
+
 		resBytes, e := json.Marshal(res)
 		if e != nil {
 			return nil, errors.WrapError(e, "failed to marshal response")
@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+)
+
+// CheckDependencies returns the dependencies without duplicates, failing if any of them is not one of the current clauses
+func CheckDependencies(dependencies []interface{}, mapOfCurrClauses map[string]interface{}) ([]interface{}, errors.ICCError) {
+	newDependencies := []interface{}{}
+	added := make(map[string]bool)
+	for _, depInterface := range dependencies {
+		dep, ok := depInterface.(assets.Key)
+		if !ok {
+			return nil, errors.NewCCError("Dependencies must be clause keys", http.StatusBadRequest)
+		}
+
+		key := dep.Key()
+		if _, exists := mapOfCurrClauses[key]; !exists {
+			return nil, errors.NewCCError(fmt.Sprintf("Dependency %s is not a clause of the contract", key), http.StatusBadRequest)
+		}
+
+		if !added[key] {
+			added[key] = true
+			newDependencies = append(newDependencies, depInterface)
+		}
+	}
+	return newDependencies, nil
+}
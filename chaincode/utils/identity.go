@@ -0,0 +1,187 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	"github.com/hyperledger/fabric-chaincode-go/pkg/cid"
+)
+
+// Identity is the X.509 identity of the client submitting the transaction.
+// EnrollmentId is the common name of the certificate, which Fabric CA sets to the enrollment ID.
+type Identity struct {
+	MspId           string `json:"mspId"`
+	EnrollmentId    string `json:"enrollmentId"`
+	CertFingerprint string `json:"certFingerprint"`
+	Admin           bool   `json:"admin"` // the certificate has the admin node OU
+}
+
+// CallerIdentity resolves the identity of the transaction client
+func CallerIdentity(stub *sw.StubWrapper) (*Identity, errors.ICCError) {
+	clientIdentity, err := cid.New(stub.Stub)
+	if err != nil {
+		return nil, errors.WrapError(err, "Failed to get client identity")
+	}
+
+	mspId, err := clientIdentity.GetMSPID()
+	if err != nil {
+		return nil, errors.WrapError(err, "Failed to get client MSP ID")
+	}
+
+	cert, err := clientIdentity.GetX509Certificate()
+	if err != nil {
+		return nil, errors.WrapError(err, "Failed to get client certificate")
+	}
+	if cert == nil {
+		return nil, errors.NewCCError("Client identity is not an X.509 certificate", http.StatusForbidden)
+	}
+
+	fingerprint := sha256.Sum256(cert.Raw)
+
+	admin, err := clientIdentity.HasOUValue("admin")
+	if err != nil {
+		return nil, errors.WrapError(err, "Failed to get client organizational units")
+	}
+
+	return &Identity{
+		MspId:           mspId,
+		EnrollmentId:    cert.Subject.CommonName,
+		CertFingerprint: hex.EncodeToString(fingerprint[:]),
+		Admin:           admin,
+	}, nil
+}
+
+// CheckCallerIsAdmin fails unless the transaction client is an admin of the MSP
+func CheckCallerIsAdmin(stub *sw.StubWrapper, mspId string) errors.ICCError {
+	caller, err := CallerIdentity(stub)
+	if err != nil {
+		return err
+	}
+
+	if !caller.Admin || caller.MspId != mspId {
+		return errors.NewCCError("Only an admin of "+mspId+" can perform this action", http.StatusForbidden)
+	}
+
+	return nil
+}
+
+// IsUser tells whether the identity is the one bound to the user asset.
+// Users without a bound identity match no one. The certificate fingerprint is only
+// compared when the user pins one.
+func (id *Identity) IsUser(user map[string]interface{}) bool {
+	mspId, _ := user["mspId"].(string)
+	enrollmentId, _ := user["enrollmentId"].(string)
+	if mspId == "" || enrollmentId == "" {
+		return false
+	}
+	if mspId != id.MspId || enrollmentId != id.EnrollmentId {
+		return false
+	}
+
+	fingerprint, _ := user["certFingerprint"].(string)
+	return fingerprint == "" || fingerprint == id.CertFingerprint
+}
+
+// CheckCallerIsUser fails unless the transaction client is bound to the referenced user or,
+// when the user is the party user of a company, to one of its legal representatives
+func CheckCallerIsUser(stub *sw.StubWrapper, userRef interface{}) errors.ICCError {
+	return CheckCallerIsAnyUser(stub, []interface{}{userRef})
+}
+
+// CheckCallerIsAnyUser fails unless the transaction client is bound to one of the referenced users.
+// References can be asset keys or maps with the user @key.
+func CheckCallerIsAnyUser(stub *sw.StubWrapper, userRefs []interface{}) errors.ICCError {
+	caller, err := CallerIdentity(stub)
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range userRefs {
+		var key string
+		switch r := ref.(type) {
+		case assets.Key:
+			key = r.Key()
+		case assets.Asset:
+			key = r.Key()
+		case map[string]interface{}:
+			key, _ = r["@key"].(string)
+		}
+		if key == "" {
+			continue
+		}
+
+		userKey := assets.Key{"@assetType": "user", "@key": key}
+		user, err := userKey.Get(stub)
+		if err != nil {
+			return errors.WrapError(err, "Failed to get user from ledger")
+		}
+
+		if caller.IsUser(*user) {
+			return nil
+		}
+
+		// Companies act through their legal representatives
+		representative, err := callerRepresentative(stub, caller, *user)
+		if err != nil {
+			return err
+		}
+		if representative != "" {
+			return nil
+		}
+	}
+
+	return errors.NewCCError("Caller is not allowed to perform this action", http.StatusForbidden)
+}
+
+// CallerRepresentative returns the key of the legal representative the transaction client is bound to
+// when the referenced user is the party user of a company, or an empty string otherwise
+func CallerRepresentative(stub *sw.StubWrapper, userRef interface{}) (string, errors.ICCError) {
+	keys := RefKeys([]interface{}{userRef})
+	if len(keys) != 1 {
+		return "", nil
+	}
+
+	caller, err := CallerIdentity(stub)
+	if err != nil {
+		return "", err
+	}
+
+	userKey := assets.Key{"@assetType": "user", "@key": keys[0]}
+	user, err := userKey.Get(stub)
+	if err != nil {
+		return "", errors.WrapError(err, "Failed to get user from ledger")
+	}
+
+	return callerRepresentative(stub, caller, *user)
+}
+
+func callerRepresentative(stub *sw.StubWrapper, caller *Identity, user map[string]interface{}) (string, errors.ICCError) {
+	companyKeys := RefKeys([]interface{}{user["company"]})
+	if len(companyKeys) != 1 {
+		return "", nil
+	}
+
+	companyKey := assets.Key{"@assetType": "company", "@key": companyKeys[0]}
+	company, err := companyKey.Get(stub)
+	if err != nil {
+		return "", errors.WrapError(err, "Failed to get company from ledger")
+	}
+
+	for _, key := range RefKeys((*company)["representatives"]) {
+		representativeKey := assets.Key{"@assetType": "user", "@key": key}
+		representative, err := representativeKey.Get(stub)
+		if err != nil {
+			return "", errors.WrapError(err, "Failed to get representative from ledger")
+		}
+
+		if caller.IsUser(*representative) {
+			return key, nil
+		}
+	}
+
+	return "", nil
+}
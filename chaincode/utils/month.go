@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ParseMonth parses a date given as RFC3339, "2006-01-02" or "2006-01" and returns the first day of its month in UTC
+func ParseMonth(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{time.RFC3339, "2006-01-02", "2006-01"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return StartOfMonth(t), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+// StartOfMonth returns the first day of the month of t, read in the offset of t, in UTC
+func StartOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseMonth(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"2024-02-01T00:00:00-03:00", time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"2024-02-01T00:00:00+03:00", time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"2024-01-31T23:00:00-03:00", time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"2024-02-15", time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"2024-02", time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseMonth(tt.value)
+		if err != nil {
+			t.Fatalf("ParseMonth(%q) error = %v", tt.value, err)
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseMonth(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+
+	if _, err := ParseMonth("February"); err == nil {
+		t.Error("ParseMonth accepted an invalid date")
+	}
+}
@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hyperledger-labs/cc-tools/errors"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// Page sizes of the paginated queries
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 200
+)
+
+// PaginationArgs are the arguments of the paginated queries. Paginated queries must be read-only transactions.
+var PaginationArgs = []tx.Argument{
+	{
+		Tag:         "pageSize",
+		Label:       "Page Size",
+		DataType:    "number",
+		Description: fmt.Sprintf("Number of results per page. Defaults to %d, at most %d", DefaultPageSize, MaxPageSize),
+	},
+	{
+		Tag:         "bookmark",
+		Label:       "Bookmark",
+		DataType:    "string",
+		Description: "Bookmark returned on the metadata of the previous page",
+	},
+	{
+		Tag:         "sortBy",
+		Label:       "Sort By",
+		DataType:    "string",
+		Description: "Field the results are sorted by",
+	},
+	{
+		Tag:         "sortOrder",
+		Label:       "Sort Order",
+		DataType:    "string",
+		Description: "asc (default) or desc",
+	},
+}
+
+// PaginatedQuery builds a CouchDB query for assets.Search with the pagination arguments of the request.
+// Sorting is only allowed on the given fields, each served by an index on the asset type and the field,
+// so results missing the sort field are not returned.
+func PaginatedQuery(req map[string]interface{}, selector map[string]interface{}, sortFields map[string]string) (map[string]interface{}, errors.ICCError) {
+	pageSize := float64(DefaultPageSize)
+	if size, ok := req["pageSize"].(float64); ok {
+		if size < 1 || size > MaxPageSize || size != float64(int(size)) {
+			return nil, errors.NewCCError(fmt.Sprintf("Parameter 'pageSize' must be an integer between 1 and %d", MaxPageSize), http.StatusBadRequest)
+		}
+		pageSize = size
+	}
+
+	query := map[string]interface{}{
+		"selector": selector,
+		"limit":    pageSize,
+	}
+	if bookmark, ok := req["bookmark"].(string); ok && bookmark != "" {
+		query["bookmark"] = bookmark
+	}
+
+	sortBy, _ := req["sortBy"].(string)
+	if sortBy == "" {
+		return query, nil
+	}
+
+	index, ok := sortFields[sortBy]
+	if !ok {
+		allowed := make([]string, 0, len(sortFields))
+		for field := range sortFields {
+			allowed = append(allowed, field)
+		}
+		return nil, errors.NewCCError(fmt.Sprintf("Results can only be sorted by %s", strings.Join(allowed, ", ")), http.StatusBadRequest)
+	}
+
+	order := "asc"
+	if o, ok := req["sortOrder"].(string); ok && o != "" {
+		if o != "asc" && o != "desc" {
+			return nil, errors.NewCCError("Parameter 'sortOrder' must be asc or desc", http.StatusBadRequest)
+		}
+		order = o
+	}
+
+	// CouchDB sorts on every field of the index in the same direction
+	query["sort"] = []interface{}{
+		map[string]interface{}{"@assetType": order},
+		map[string]interface{}{sortBy: order},
+	}
+	query["use_index"] = []interface{}{"_design/" + index + "Doc", index}
+
+	return query, nil
+}
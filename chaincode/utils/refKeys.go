@@ -0,0 +1,23 @@
+package utils
+
+import "github.com/hyperledger-labs/cc-tools/assets"
+
+// RefKeys returns the @key of each asset reference in a list
+func RefKeys(refs interface{}) []string {
+	list, _ := refs.([]interface{})
+
+	var keys []string
+	for _, r := range list {
+		switch ref := r.(type) {
+		case map[string]interface{}:
+			if key, ok := ref["@key"].(string); ok {
+				keys = append(keys, key)
+			}
+		case assets.Key:
+			keys = append(keys, ref.Key())
+		case assets.Asset:
+			keys = append(keys, ref.Key())
+		}
+	}
+	return keys
+}
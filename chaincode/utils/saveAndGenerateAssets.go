@@ -7,29 +7,81 @@ import (
 	"github.com/hyperledger-labs/clausia-cc/chaincode/txdefs/contract/models"
 )
 
-func SaveGeneratedAssets(stub *sw.StubWrapper, genAssets []map[string]interface{}, contract *models.AutoExecutableContract, c *models.Clause) errors.ICCError {
+// SaveGeneratedAssets builds the assets generated by a clause and saves them on the ledger
+func SaveGeneratedAssets(stub *sw.StubWrapper, genAssets []map[string]interface{}, contract *models.AutoExecutableContract, c *models.Clause) ([]*assets.Asset, errors.ICCError) {
+	generated, err := GenerateAssets(genAssets, contract, c)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, asset := range generated {
+		// Save on ledger
+		_, err = asset.PutNew(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to save asset on ledger")
+		}
+	}
+
+	return generated, nil
+}
+
+// GenerateAssets builds the assets generated by a clause, without saving them.
+// Properties of the asset type that reference a contract or a clause and were not
+// set by the action are filled with the executing contract and clause.
+func GenerateAssets(genAssets []map[string]interface{}, contract *models.AutoExecutableContract, c *models.Clause) ([]*assets.Asset, errors.ICCError) {
+	var generated []*assets.Asset
 	for _, a := range genAssets {
-		// Add contract and clause infos
-		a["autoExecutableContract"] = map[string]interface{}{
-			"@assetType": "autoExecutableContract",
-			"@key":       contract.Key,
+		assetTypeTag, _ := a["@assetType"].(string)
+		assetType := assets.FetchAssetType(assetTypeTag)
+		if assetType == nil {
+			return nil, errors.NewCCError("Generated asset has an unknown asset type: "+assetTypeTag, 400)
 		}
-		a["clause"] = map[string]interface{}{
-			"@assetType": "clause",
-			"@key":       c.Key,
+
+		// Add contract and clause infos
+		for _, prop := range assetType.Props {
+			if a[prop.Tag] != nil {
+				continue
+			}
+			switch prop.DataType {
+			case "->autoExecutableContract":
+				a[prop.Tag] = map[string]interface{}{
+					"@assetType": "autoExecutableContract",
+					"@key":       contract.Key,
+				}
+			case "->clause":
+				a[prop.Tag] = map[string]interface{}{
+					"@assetType": "clause",
+					"@key":       c.Key,
+				}
+			}
 		}
 
-		// Save on ledger
 		asset, err := assets.NewAsset(a)
 		if err != nil {
-			return errors.WrapError(err, "Failed to create asset")
+			return nil, errors.WrapError(err, "Failed to create asset")
 		}
 
-		_, err = asset.PutNew(stub)
-		if err != nil {
-			return errors.WrapError(err, "Failed to save asset on ledger")
-		}
+		generated = append(generated, &asset)
+	}
+
+	return generated, nil
+}
+
+// RecordGeneratedAssets adds references to the generated assets to the "generatedAssets"
+// list of the contract data, so later clauses can refer to them
+func RecordGeneratedAssets(data map[string]interface{}, generated []*assets.Asset) map[string]interface{} {
+	if data == nil {
+		data = make(map[string]interface{})
+	}
+
+	refs, _ := data["generatedAssets"].([]interface{})
+	for _, a := range generated {
+		refs = append(refs, map[string]interface{}{
+			"@assetType": a.TypeTag(),
+			"@key":       a.Key(),
+		})
 	}
+	data["generatedAssets"] = refs
 
-	return nil
+	return data
 }
@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/clausia-cc/chaincode/datatypes"
+)
+
+// Signature algorithms accepted on documents. The signed message is always the SHA-256
+// hash of the document, so ECDSA and RSA signatures are the usual SHA256withECDSA and
+// SHA256withRSA over the document, and Ed25519 signs the 32 hash bytes.
+const (
+	AlgorithmECDSA       = "ecdsa-sha256"
+	AlgorithmEd25519     = "ed25519"
+	AlgorithmRSAPKCS1v15 = "rsa-pkcs1v15-sha256"
+	AlgorithmRSAPSS      = "rsa-pss-sha256"
+)
+
+const invalidSignatureError = "Signature does not match the document hash and the signer public key"
+
+// VerifySignature checks the signature of a document hash with a PEM encoded public key.
+// When the algorithm is empty it is inferred from the key type. The algorithm used is returned.
+func VerifySignature(pubKey, algorithm string, hash, signature []byte) (string, errors.ICCError) {
+	key, err := datatypes.ParsePublicKey(pubKey)
+	if err != nil {
+		return "", err
+	}
+
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		if algorithm == "" {
+			algorithm = AlgorithmECDSA
+		}
+		if algorithm != AlgorithmECDSA {
+			return "", unsupportedAlgorithm(algorithm, "ECDSA")
+		}
+
+		var sig struct{ R, S *big.Int }
+		rest, nerr := asn1.Unmarshal(signature, &sig)
+		if nerr != nil || len(rest) > 0 || sig.R == nil || sig.S == nil {
+			return "", errors.NewCCError("ECDSA signature must be ASN.1 DER encoded", http.StatusBadRequest)
+		}
+		if !ecdsa.Verify(k, hash, sig.R, sig.S) {
+			return "", errors.NewCCError(invalidSignatureError, http.StatusBadRequest)
+		}
+
+	case ed25519.PublicKey:
+		if algorithm == "" {
+			algorithm = AlgorithmEd25519
+		}
+		if algorithm != AlgorithmEd25519 {
+			return "", unsupportedAlgorithm(algorithm, "Ed25519")
+		}
+		if !ed25519.Verify(k, hash, signature) {
+			return "", errors.NewCCError(invalidSignatureError, http.StatusBadRequest)
+		}
+
+	case *rsa.PublicKey:
+		if algorithm == "" {
+			algorithm = AlgorithmRSAPKCS1v15
+		}
+
+		var nerr error
+		switch algorithm {
+		case AlgorithmRSAPKCS1v15:
+			nerr = rsa.VerifyPKCS1v15(k, crypto.SHA256, hash, signature)
+		case AlgorithmRSAPSS:
+			nerr = rsa.VerifyPSS(k, crypto.SHA256, hash, signature, nil)
+		default:
+			return "", unsupportedAlgorithm(algorithm, "RSA")
+		}
+		if nerr != nil {
+			return "", errors.NewCCError(invalidSignatureError, http.StatusBadRequest)
+		}
+	}
+
+	return algorithm, nil
+}
+
+func unsupportedAlgorithm(algorithm, keyType string) errors.ICCError {
+	return errors.NewCCError(fmt.Sprintf("Algorithm '%s' cannot be used with an %s key", algorithm, keyType), http.StatusBadRequest)
+}
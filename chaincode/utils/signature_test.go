@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+)
+
+func pemKey(t *testing.T, key interface{}) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func TestVerifySignature(t *testing.T) {
+	hash := sha256.Sum256([]byte("document"))
+	otherHash := sha256.Sum256([]byte("other document"))
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecSig, err := ecdsa.SignASN1(rand.Reader, ecKey, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edSig := ed25519.Sign(edKey, hash[:])
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcsSig, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	pssSig, err := rsa.SignPSS(rand.Reader, rsaKey, crypto.SHA256, hash[:], nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ecPem := pemKey(t, &ecKey.PublicKey)
+	edPem := pemKey(t, edPub)
+	rsaPem := pemKey(t, &rsaKey.PublicKey)
+
+	tests := []struct {
+		name      string
+		key       string
+		algorithm string
+		hash      []byte
+		signature []byte
+		want      string
+		wantErr   bool
+	}{
+		{"ecdsa inferred", ecPem, "", hash[:], ecSig, AlgorithmECDSA, false},
+		{"ecdsa explicit", ecPem, AlgorithmECDSA, hash[:], ecSig, AlgorithmECDSA, false},
+		{"ecdsa other hash", ecPem, "", otherHash[:], ecSig, "", true},
+		{"ecdsa not DER", ecPem, "", hash[:], []byte("raw"), "", true},
+		{"ecdsa wrong algorithm", ecPem, AlgorithmEd25519, hash[:], ecSig, "", true},
+		{"ed25519 inferred", edPem, "", hash[:], edSig, AlgorithmEd25519, false},
+		{"ed25519 other hash", edPem, "", otherHash[:], edSig, "", true},
+		{"ed25519 wrong algorithm", edPem, AlgorithmRSAPSS, hash[:], edSig, "", true},
+		{"rsa pkcs1v15 inferred", rsaPem, "", hash[:], pkcsSig, AlgorithmRSAPKCS1v15, false},
+		{"rsa pss", rsaPem, AlgorithmRSAPSS, hash[:], pssSig, AlgorithmRSAPSS, false},
+		{"rsa pss as pkcs1v15", rsaPem, AlgorithmRSAPKCS1v15, hash[:], pssSig, "", true},
+		{"rsa wrong algorithm", rsaPem, AlgorithmECDSA, hash[:], pkcsSig, "", true},
+		{"signature of another key", rsaPem, "", hash[:], edSig, "", true},
+		{"invalid key", "not a key", "", hash[:], edSig, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := VerifySignature(tt.key, tt.algorithm, tt.hash, tt.signature)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VerifySignature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("VerifySignature() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
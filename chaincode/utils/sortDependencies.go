@@ -0,0 +1,50 @@
+package utils
+
+// SortDependencies orders the nodes so that every node comes after all of its dependencies.
+// The relative order of the input is kept whenever possible. Dependencies that are not in
+// nodes are ignored. Nodes that belong to a cycle, or depend on one, are returned in cyclic.
+func SortDependencies(nodes []string, dependencies map[string][]string) (sorted []string, cyclic []string) {
+	known := make(map[string]bool, len(nodes))
+	for _, n := range nodes {
+		known[n] = true
+	}
+
+	pending := make(map[string]int, len(nodes))
+	dependents := make(map[string][]string)
+	for _, n := range nodes {
+		for _, dep := range dependencies[n] {
+			if !known[dep] {
+				continue
+			}
+			pending[n]++
+			dependents[dep] = append(dependents[dep], n)
+		}
+	}
+
+	done := make(map[string]bool, len(nodes))
+	for len(sorted) < len(nodes) {
+		progress := false
+		for _, n := range nodes {
+			if done[n] || pending[n] > 0 {
+				continue
+			}
+			done[n] = true
+			sorted = append(sorted, n)
+			for _, d := range dependents[n] {
+				pending[d]--
+			}
+			progress = true
+		}
+		if !progress {
+			break
+		}
+	}
+
+	for _, n := range nodes {
+		if !done[n] {
+			cyclic = append(cyclic, n)
+		}
+	}
+
+	return sorted, cyclic
+}